@@ -1,22 +1,270 @@
 package v1alpha1
 
 import (
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // HttpJsonCheck defines expected JSON field values from a HTTP response
 type HttpJsonCheck struct {
 	ExpectedStatusCode int               `json:"expectedStatusCode,omitempty"` // optional override for status code check
-	JsonAssertions     map[string]string `json:"jsonAssertions"`               // key: JSONPath-like dot string, value: expected value
+	JsonAssertions     map[string]string `json:"jsonAssertions,omitempty"`     // key: JSONPath-like dot string, value: expected value (shorthand for "eq")
+	Assertions         []JsonAssertion   `json:"assertions,omitempty"`         // typed assertions evaluated with full JSONPath
+}
+
+// JsonAssertion compares the values selected by a JSONPath expression against an expected value
+type JsonAssertion struct {
+	Path     string   `json:"path"`               // JSONPath expression, e.g. "$.nodes[0].status" or "{.items[?(@.name=='db')].state}"
+	Operator string   `json:"operator,omitempty"` // eq (default), ne, gt, lt, gte, lte, in, regex, exists, length
+	Value    string   `json:"value,omitempty"`    // expected value; for "exists" use "false" to assert absence
+	Values   []string `json:"values,omitempty"`   // accepted values for the "in" operator
+	Type     string   `json:"type,omitempty"`     // string, number or boolean; inferred from the JSON value when empty
+}
+
+// PingCheck configures the ICMP echo driver
+type PingCheck struct {
+	Count         int              `json:"count,omitempty"`         // echo requests per check (default 3)
+	Interval      *metav1.Duration `json:"interval,omitempty"`      // delay between requests (default 1s)
+	Timeout       *metav1.Duration `json:"timeout,omitempty"`       // wait for each reply (default 2s)
+	PacketSize    int              `json:"packetSize,omitempty"`    // payload size in bytes (default 56)
+	MaxPacketLoss *int32           `json:"maxPacketLoss,omitempty"` // tolerated loss in percent; by default only total loss fails
+	IPVersion     string           `json:"ipVersion,omitempty"`     // "ipv4" or "ipv6"; by default follows the resolved address
+}
+
+// DnsCheck configures record queries and answer assertions for the DNS driver
+type DnsCheck struct {
+	RecordType       string           `json:"recordType,omitempty"`       // A, AAAA, CNAME, MX, TXT, SRV or NS; both A and AAAA when unset
+	Nameservers      []string         `json:"nameservers,omitempty"`      // "host[:port]"; defaults to the nameservers in /etc/resolv.conf
+	Protocol         string           `json:"protocol,omitempty"`         // udp (default), tcp or tls (DNS over TLS)
+	Timeout          *metav1.Duration `json:"timeout,omitempty"`          // per query (default 5s)
+	ExpectedRcode    string           `json:"expectedRcode,omitempty"`    // NOERROR (default), NXDOMAIN, SERVFAIL, REFUSED, ...
+	ExpectedAnswers  []string         `json:"expectedAnswers,omitempty"`  // exact answer set, order-insensitive, e.g. "10 mx1.example.com" for MX
+	AnswerPattern    string           `json:"answerPattern,omitempty"`    // regex that every answer must match
+	MinTTL           *int32           `json:"minTTL,omitempty"`           // lowest acceptable TTL in seconds
+	MaxTTL           *int32           `json:"maxTTL,omitempty"`           // highest acceptable TTL in seconds
+	CompareResolvers bool             `json:"compareResolvers,omitempty"` // query every nameserver and fail when their answers differ
+}
+
+// TcpCheck configures a send/expect conversation for the TCP driver
+type TcpCheck struct {
+	Steps          []TcpStep        `json:"steps,omitempty"`          // executed in order once connected
+	ConnectTimeout *metav1.Duration `json:"connectTimeout,omitempty"` // default 10s
+	ReadTimeout    *metav1.Duration `json:"readTimeout,omitempty"`    // wait for each expected response (default 5s)
+	TLS            *TLSConfig       `json:"tls,omitempty"`            // negotiate TLS right after connecting
+}
+
+// TcpStep sends an optional payload and then optionally waits for a matching response
+type TcpStep struct {
+	Send    string `json:"send,omitempty"`    // text payload, e.g. "PING\r\n" (escapes are interpreted by YAML double quotes)
+	SendHex string `json:"sendHex,omitempty"` // binary payload as hex, used instead of send
+	Expect  string `json:"expect,omitempty"`  // regex matched against the bytes received, e.g. "^\\+PONG"
+}
+
+// UdpCheck configures the payload and expected response of the UDP driver
+type UdpCheck struct {
+	Send    string           `json:"send,omitempty"`    // text payload
+	SendHex string           `json:"sendHex,omitempty"` // binary payload as hex, used instead of send
+	Expect  string           `json:"expect,omitempty"`  // regex the response must match; without it silence counts as success
+	Timeout *metav1.Duration `json:"timeout,omitempty"` // wait for a response per attempt (default 2s)
+	Retries *int32           `json:"retries,omitempty"` // additional attempts when no response arrives (default 2)
+}
+
+// PostgresCheck configures the PostgreSQL driver
+type PostgresCheck struct {
+	Database             string                `json:"database,omitempty"`             // default "postgres"
+	CredentialsSecretRef *CredentialsSecretRef `json:"credentialsSecretRef,omitempty"` // Secret in the monitor namespace
+	SSLMode              string                `json:"sslMode,omitempty"`              // disable, require (default), verify-ca or verify-full
+	Query                string                `json:"query,omitempty"`                // default "SELECT 1"
+	ExpectedResult       string                `json:"expectedResult,omitempty"`       // compared with the first column of the first row
+	ExpectedRole         string                `json:"expectedRole,omitempty"`         // "primary" or "standby"
+	MaxReplicationLag    *metav1.Duration      `json:"maxReplicationLag,omitempty"`    // only evaluated on standbys
+	ConnectTimeout       *metav1.Duration      `json:"connectTimeout,omitempty"`       // default 10s
+}
+
+// MysqlCheck configures the MySQL/MariaDB driver
+type MysqlCheck struct {
+	Database             string                `json:"database,omitempty"`             // schema to connect to, optional
+	CredentialsSecretRef *CredentialsSecretRef `json:"credentialsSecretRef,omitempty"` // Secret in the monitor namespace
+	TLSMode              string                `json:"tlsMode,omitempty"`              // false, preferred (default), true or skip-verify
+	Query                string                `json:"query,omitempty"`                // default "SELECT 1"
+	ExpectedResult       string                `json:"expectedResult,omitempty"`       // compared with the first column of the first row
+	ExpectedRole         string                `json:"expectedRole,omitempty"`         // "primary" (read_only off) or "standby" (read_only on)
+	MaxReplicationLag    *metav1.Duration      `json:"maxReplicationLag,omitempty"`    // compared with Seconds_Behind_Source; requires REPLICATION CLIENT
+	ConnectTimeout       *metav1.Duration      `json:"connectTimeout,omitempty"`       // default 10s
+}
+
+// RedisCheck configures the Redis driver
+type RedisCheck struct {
+	Mode                 string                `json:"mode,omitempty"`                 // standalone (default), sentinel or cluster
+	MasterName           string                `json:"masterName,omitempty"`           // sentinel mode: name of the monitored master
+	CredentialsSecretRef *CredentialsSecretRef `json:"credentialsSecretRef,omitempty"` // password and optional ACL user
+	TLS                  *TLSConfig            `json:"tls,omitempty"`
+	ExpectedRole         string                `json:"expectedRole,omitempty"`      // "master" or "replica" from INFO replication
+	RequireMasterLink    bool                  `json:"requireMasterLink,omitempty"` // fail replicas whose master_link_status is not up
+	MaxMemoryPercent     *int32                `json:"maxMemoryPercent,omitempty"`  // used_memory as a percentage of maxmemory
+	MaxUsedMemory        *resource.Quantity    `json:"maxUsedMemory,omitempty"`     // absolute used_memory limit, e.g. "2Gi"
+	Timeout              *metav1.Duration      `json:"timeout,omitempty"`           // per connection (default 5s)
+}
+
+// KafkaCheck configures the Kafka driver; the endpoint is a comma-separated list of bootstrap servers
+type KafkaCheck struct {
+	Topics         []string             `json:"topics,omitempty"`         // topics whose partitions must be online and in sync; empty checks all topics
+	MinBrokers     *int32               `json:"minBrokers,omitempty"`     // minimum brokers reported by metadata
+	SASL           *KafkaSASL           `json:"sasl,omitempty"`           // SASL authentication
+	TLS            *TLSConfig           `json:"tls,omitempty"`            // serverName defaults to each broker host
+	TLSSecretRef   *TLSSecretRef        `json:"tlsSecretRef,omitempty"`   // CA bundle and optional client certificate; implies TLS
+	ConsumerGroups []KafkaConsumerGroup `json:"consumerGroups,omitempty"` // groups whose lag is checked
+	Timeout        *metav1.Duration     `json:"timeout,omitempty"`        // whole check (default 10s)
+}
+
+// KafkaSASL configures SASL authentication for the Kafka driver
+type KafkaSASL struct {
+	Mechanism            string               `json:"mechanism"` // PLAIN, SCRAM-SHA-256 or SCRAM-SHA-512
+	CredentialsSecretRef CredentialsSecretRef `json:"credentialsSecretRef"`
+}
+
+// KafkaConsumerGroup sets the lag threshold for a consumer group
+type KafkaConsumerGroup struct {
+	Name   string   `json:"name"`
+	MaxLag int64    `json:"maxLag"`           // total lag in messages across the group's partitions
+	Topics []string `json:"topics,omitempty"` // only count lag on these topics
+}
+
+// TLSSecretRef points at a Secret holding PEM encoded TLS material
+type TLSSecretRef struct {
+	Name    string `json:"name"`
+	CAKey   string `json:"caKey,omitempty"`   // default "ca.crt"
+	CertKey string `json:"certKey,omitempty"` // client certificate, default "tls.crt"; optional
+	KeyKey  string `json:"keyKey,omitempty"`  // client key, default "tls.key"; optional
+}
+
+// TrinoCheck configures the Trino driver beyond the coordinator /v1/info check
+type TrinoCheck struct {
+	Query             string           `json:"query,omitempty"`             // SQL executed through /v1/statement, e.g. "SELECT 1 FROM hive.sales.orders LIMIT 1"
+	User              string           `json:"user,omitempty"`              // X-Trino-User (default "endpoint-monitor")
+	Catalog           string           `json:"catalog,omitempty"`           // X-Trino-Catalog
+	Schema            string           `json:"schema,omitempty"`            // X-Trino-Schema
+	MinActiveWorkers  *int32           `json:"minActiveWorkers,omitempty"`  // minimum nodes reported by /v1/node
+	WarningQueryTime  *metav1.Duration `json:"warningQueryTime,omitempty"`  // slower queries report degraded
+	CriticalQueryTime *metav1.Duration `json:"criticalQueryTime,omitempty"` // slower queries fail the check
+	Timeout           *metav1.Duration `json:"timeout,omitempty"`           // whole check including the query (default 30s)
+}
+
+// OpenSearchCheck configures the health policy of the OpenSearch/Elasticsearch driver
+type OpenSearchCheck struct {
+	AcceptedStatuses       []string              `json:"acceptedStatuses,omitempty"`       // cluster statuses reported as healthy (default ["green"])
+	DegradedStatuses       []string              `json:"degradedStatuses,omitempty"`       // statuses reported as degraded, e.g. ["yellow"]; anything else fails
+	MinNodes               *int32                `json:"minNodes,omitempty"`               // minimum number_of_nodes
+	MinDataNodes           *int32                `json:"minDataNodes,omitempty"`           // minimum number_of_data_nodes
+	MaxUnassignedShards    *int32                `json:"maxUnassignedShards,omitempty"`    // maximum unassigned_shards
+	MaxPendingTasks        *int32                `json:"maxPendingTasks,omitempty"`        // maximum number_of_pending_tasks
+	MinActiveShardsPercent *int32                `json:"minActiveShardsPercent,omitempty"` // minimum active_shards_percent_as_number
+	Indices                []string              `json:"indices,omitempty"`                // indices checked individually via /_cluster/health/<index>
+	CredentialsSecretRef   *CredentialsSecretRef `json:"credentialsSecretRef,omitempty"`   // basic auth
+	APIKeySecretRef        *SecretKeyRef         `json:"apiKeySecretRef,omitempty"`        // encoded API key sent as "Authorization: ApiKey <key>"
+}
+
+// PromqlCheck configures the PromQL driver; the endpoint is the base URL of a Prometheus-compatible API
+type PromqlCheck struct {
+	Query                string                `json:"query"`                          // instant query, e.g. sum(rate(http_requests_total{code=~"5.."}[5m])) / sum(rate(http_requests_total[5m]))
+	Operator             string                `json:"operator,omitempty"`             // gt, lt, gte, lte, eq or ne; every sample must satisfy "value <operator> threshold"
+	Threshold            string                `json:"threshold,omitempty"`            // number compared with each sample
+	FailOnEmpty          bool                  `json:"failOnEmpty,omitempty"`          // fail when the query returns no samples
+	CredentialsSecretRef *CredentialsSecretRef `json:"credentialsSecretRef,omitempty"` // basic auth
+	BearerTokenSecretRef *SecretKeyRef         `json:"bearerTokenSecretRef,omitempty"` // sent as "Authorization: Bearer <token>"
+	Timeout              *metav1.Duration      `json:"timeout,omitempty"`              // default 30s
+}
+
+// KubernetesCheck configures the Kubernetes driver. The endpoint names the target as
+// "<kind>/<name>" (service, deployment, statefulset) or "pod" together with selector.
+type KubernetesCheck struct {
+	Namespace   string                `json:"namespace,omitempty"`   // defaults to the monitor namespace; others need --allow-cross-namespace-targets
+	Selector    *metav1.LabelSelector `json:"selector,omitempty"`    // pods to check when the endpoint is "pod"
+	MinReady    *int32                `json:"minReady,omitempty"`    // minimum ready endpoints or pods (default 1)
+	MaxRestarts *int32                `json:"maxRestarts,omitempty"` // container restarts above which a pod counts as crash-looping
+}
+
+// SecretKeyRef selects a single key of a Secret
+type SecretKeyRef struct {
+	Name string `json:"name"`
+	Key  string `json:"key"`
+}
+
+// CredentialsSecretRef points at a Secret holding login credentials
+type CredentialsSecretRef struct {
+	Name        string `json:"name"`
+	UsernameKey string `json:"usernameKey,omitempty"` // default "username"
+	PasswordKey string `json:"passwordKey,omitempty"` // default "password"
+}
+
+// TLSConfig configures TLS for drivers that connect over plain sockets
+type TLSConfig struct {
+	Enabled            bool   `json:"enabled"`
+	ServerName         string `json:"serverName,omitempty"`         // defaults to the endpoint host
+	InsecureSkipVerify bool   `json:"insecureSkipVerify,omitempty"` // skip certificate verification
 }
 
 // EndpointMonitorSpec defines the desired state of EndpointMonitor
+// +kubebuilder:validation:XValidation:rule="has(self.endpoint) || (has(self.endpoints) && size(self.endpoints) > 0)",message="either endpoint or endpoints must be set"
 type EndpointMonitorSpec struct {
-	Driver        string         `json:"driver"`        // ex: "opensearch", "trino", "http", "http-json"
-	Endpoint      string         `json:"endpoint"`      // target service URL
-	CheckInterval int            `json:"checkInterval"` // in seconds
-	Notify        NotifyConfig   `json:"notify"`
-	HttpJsonCheck *HttpJsonCheck `json:"httpJsonCheck,omitempty"` // only relevant for driver = "http-json"
+	Driver          string           `json:"driver"`                  // ex: "opensearch", "trino", "http", "http-json", "tcp", "udp"
+	Endpoint        string           `json:"endpoint,omitempty"`      // target service URL
+	CheckInterval   int              `json:"checkInterval,omitempty"` // in seconds; see also interval and schedule
+	Notify          NotifyConfig     `json:"notify"`
+	Suspend         bool             `json:"suspend,omitempty"`         // stops checks until unset; status is kept
+	HttpJsonCheck   *HttpJsonCheck   `json:"httpJsonCheck,omitempty"`   // only relevant for driver = "http-json"
+	PingCheck       *PingCheck       `json:"pingCheck,omitempty"`       // only relevant for driver = "ping"
+	DnsCheck        *DnsCheck        `json:"dnsCheck,omitempty"`        // only relevant for driver = "dns"
+	TcpCheck        *TcpCheck        `json:"tcpCheck,omitempty"`        // only relevant for driver = "tcp"
+	UdpCheck        *UdpCheck        `json:"udpCheck,omitempty"`        // only relevant for driver = "udp"
+	PostgresCheck   *PostgresCheck   `json:"postgresCheck,omitempty"`   // only relevant for driver = "postgres"
+	MysqlCheck      *MysqlCheck      `json:"mysqlCheck,omitempty"`      // only relevant for driver = "mysql"
+	RedisCheck      *RedisCheck      `json:"redisCheck,omitempty"`      // only relevant for driver = "redis"
+	KafkaCheck      *KafkaCheck      `json:"kafkaCheck,omitempty"`      // only relevant for driver = "kafka"
+	TrinoCheck      *TrinoCheck      `json:"trinoCheck,omitempty"`      // only relevant for driver = "trino"
+	OpenSearchCheck *OpenSearchCheck `json:"openSearchCheck,omitempty"` // only relevant for driver = "opensearch" or "elasticsearch"
+	PromqlCheck     *PromqlCheck     `json:"promqlCheck,omitempty"`     // only relevant for driver = "promql"
+	KubernetesCheck *KubernetesCheck `json:"kubernetesCheck,omitempty"` // only relevant for driver = "kubernetes"
+
+	// SuccessExpression is an optional CEL expression that decides whether a check succeeded.
+	// Available variables: success (driver verdict), responseTime (duration), statusCode (int),
+	// headers (map of lower-cased names), body (decoded JSON) and details (driver-specific fields).
+	// Example: "body.readyReplicas >= 2 && body.version.startsWith('4.')"
+	SuccessExpression string `json:"successExpression,omitempty"`
+
+	// Endpoints are checked in parallel instead of Endpoint and reported as one aggregate status.
+	// Aggregation decides how many endpoints must be up (success or degraded): "all" (default),
+	// "any" or "quorum", which requires Quorum endpoints or, without it, a majority.
+	Endpoints   []string `json:"endpoints,omitempty"`
+	Aggregation string   `json:"aggregation,omitempty"`
+	Quorum      *int32   `json:"quorum,omitempty"`
+
+	// DependsOn lists monitors this one relies on. While any of them is failing, checks still
+	// run but notifications are suppressed, and the failing monitor's alert lists its dependents.
+	DependsOn []MonitorReference `json:"dependsOn,omitempty"`
+
+	// Interval replaces CheckInterval with a duration such as "90s" or "1m30s". Interval-based
+	// checks run at a fixed per-monitor offset within the interval, derived from the monitor's
+	// name, so monitors created together are spread out instead of firing in lockstep.
+	Interval *metav1.Duration `json:"interval,omitempty"`
+	// Schedule runs checks on a five-field cron expression instead, e.g. "*/5 * * * *",
+	// evaluated in TimeZone (IANA name, default UTC).
+	Schedule string `json:"schedule,omitempty"`
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// HistoryLimit bounds status.history, the most recent check results (default 30, at most 100)
+	HistoryLimit *int32 `json:"historyLimit,omitempty"`
+
+	// Latency thresholds applied to successful checks: above WarningLatency the monitor is
+	// reported as "degraded", above CriticalLatency it is reported as "failure".
+	WarningLatency  *metav1.Duration `json:"warningLatency,omitempty"`  // e.g. "2s"
+	CriticalLatency *metav1.Duration `json:"criticalLatency,omitempty"` // e.g. "10s"
+}
+
+// MonitorReference points to another EndpointMonitor
+type MonitorReference struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"` // defaults to the namespace of the referencing monitor
 }
 
 // NotifyConfig holds notifier configurations
@@ -29,7 +277,8 @@ type NotifyConfig struct {
 type SlackConfig struct {
 	Enabled    bool     `json:"enabled"`
 	WebhookURL string   `json:"webhookUrl"`
-	AlertOn    []string `json:"alertOn,omitempty"` // values: "success", "failure"
+	Channel    string   `json:"channel,omitempty"` // overrides the webhook's default channel, e.g. "#payments-alerts"
+	AlertOn    []string `json:"alertOn,omitempty"` // values: "success", "degraded", "failure"
 }
 
 // EmailConfig is placeholder (no-op for now)
@@ -39,6 +288,7 @@ type EmailConfig struct {
 	To             []string  `json:"to"`
 	EmailProvider  string    `json:"emailProvider"` // e.g., "ses"
 	EmailSecretRef SecretRef `json:"emailSecretRef"`
+	AlertOn        []string  `json:"alertOn,omitempty"` // values: "success", "degraded", "failure"
 }
 
 type SecretRef struct {
@@ -47,12 +297,66 @@ type SecretRef struct {
 
 // EndpointMonitorStatus defines the observed state of EndpointMonitor
 type EndpointMonitorStatus struct {
-	LastCheckedTime metav1.Time `json:"lastCheckedTime,omitempty"`
-	LastStatus      string      `json:"lastStatus,omitempty"` // e.g., success/failure
+	// LastCheckedTime is the time of the last scheduled check; checks forced by the run-now
+	// annotation update the results but not this time, so they do not shift the schedule
+	LastCheckedTime  metav1.Time      `json:"lastCheckedTime,omitempty"`
+	LastStatus       string           `json:"lastStatus,omitempty"` // e.g., success/degraded/failure
+	LastResponseTime metav1.Duration  `json:"lastResponseTime,omitempty"`
+	LastMessage      string           `json:"lastMessage,omitempty"`
+	LastRunNow       string           `json:"lastRunNow,omitempty"` // last run-now annotation value handled
+	Endpoints        []EndpointStatus `json:"endpoints,omitempty"`  // per-endpoint results when spec.endpoints is set
+	History          []CheckRecord    `json:"history,omitempty"`    // most recent checks, oldest first
+	Uptime           *UptimeStatus    `json:"uptime,omitempty"`
+
+	// Conditions include "Suppressed", true while notifications are held back by a failing dependency,
+	// "Muted" during maintenance windows and silences, and "Suspended" while spec.suspend is set
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// CheckRecord is one entry of the check history
+type CheckRecord struct {
+	Time         metav1.Time     `json:"time"`
+	Status       string          `json:"status"` // success/degraded/failure
+	ResponseTime metav1.Duration `json:"responseTime"`
+	Message      string          `json:"message,omitempty"` // first line of the check message, shortened
+}
+
+// UptimeStatus summarizes availability; a check counts as up unless its status is failure.
+// The latencies come from the history and the uptimes from HourlyBuckets; the last hour
+// counts the current bucket and the part of the previous one still inside the hour.
+type UptimeStatus struct {
+	LastHour       string           `json:"lastHour,omitempty"` // percentage, e.g. "99.72"
+	LastDay        string           `json:"lastDay,omitempty"`
+	LastWeek       string           `json:"lastWeek,omitempty"`
+	AverageLatency *metav1.Duration `json:"averageLatency,omitempty"`
+	P95Latency     *metav1.Duration `json:"p95Latency,omitempty"`
+	HourlyBuckets  []UptimeBucket   `json:"hourlyBuckets,omitempty"` // check counts of the last 7 days, oldest first
+}
+
+// UptimeBucket counts the checks started within one hour
+type UptimeBucket struct {
+	Hour   metav1.Time `json:"hour"`
+	Checks int32       `json:"checks"`
+	Up     int32       `json:"up"`
+}
+
+// EndpointStatus is the latest check result of a single entry of spec.endpoints
+type EndpointStatus struct {
+	Endpoint     string          `json:"endpoint"`
+	Status       string          `json:"status"` // success/degraded/failure
+	ResponseTime metav1.Duration `json:"responseTime,omitempty"`
+	Message      string          `json:"message,omitempty"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Driver",type=string,JSONPath=`.spec.driver`
+//+kubebuilder:printcolumn:name="Suspend",type=boolean,JSONPath=`.spec.suspend`
+//+kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.lastStatus`
+//+kubebuilder:printcolumn:name="Latency",type=string,JSONPath=`.status.lastResponseTime`
+//+kubebuilder:printcolumn:name="Uptime 24h",type=string,JSONPath=`.status.uptime.lastDay`
+//+kubebuilder:printcolumn:name="Suppressed",type=string,JSONPath=`.status.conditions[?(@.type=="Suppressed")].status`,priority=1
+//+kubebuilder:printcolumn:name="Last Checked",type=date,JSONPath=`.status.lastCheckedTime`
 
 type EndpointMonitor struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -0,0 +1,75 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EndpointSLOSpec sets an availability objective over the checks of one or more monitors.
+// Every check counts as a good event unless its status is failure.
+type EndpointSLOSpec struct {
+	Monitors  []MonitorReference `json:"monitors"`           // monitors in the SLO's namespace unless namespace is set
+	Objective string             `json:"objective"`          // target availability in percent, e.g. "99.9"
+	Window    *metav1.Duration   `json:"window,omitempty"`   // rolling window of the objective, defaults to 720h (30 days); at most 168h without --history-path
+	Notify    NotifyConfig       `json:"notify,omitempty"`   // receives burn-rate alerts; alertOn defaults to failure, add success for resolutions
+	Alerts    []BurnRateAlert    `json:"alerts,omitempty"`   // defaults to the multi-window alerts of the SRE workbook
+	Interval  *metav1.Duration   `json:"interval,omitempty"` // how often the SLO is evaluated, defaults to 1m
+}
+
+// BurnRateAlert fires while the error budget burns at least BurnRate times faster than
+// sustainable over both windows; the short window makes it resolve quickly.
+type BurnRateAlert struct {
+	Name        string          `json:"name"`        // e.g. "page" or "ticket"
+	LongWindow  metav1.Duration `json:"longWindow"`  // e.g. "1h"
+	ShortWindow metav1.Duration `json:"shortWindow"` // e.g. "5m"
+	BurnRate    string          `json:"burnRate"`    // factor of the sustainable error rate, e.g. "14.4"
+}
+
+// EndpointSLOStatus defines the observed state of EndpointSLO
+type EndpointSLOStatus struct {
+	LastEvaluatedTime    metav1.Time        `json:"lastEvaluatedTime,omitempty"`
+	Availability         string             `json:"availability,omitempty"`         // percent over the window
+	ErrorBudgetRemaining string             `json:"errorBudgetRemaining,omitempty"` // percent of the window's budget, negative once overspent
+	Checks               int64              `json:"checks,omitempty"`               // checks in the window
+	Failures             int64              `json:"failures,omitempty"`             // failed checks in the window
+	BurnRates            []BurnRateStatus   `json:"burnRates,omitempty"`
+	FiringAlerts         []string           `json:"firingAlerts,omitempty"` // names of the alerts currently firing
+	Conditions           []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// BurnRateStatus is the burn rate of one alert's windows
+type BurnRateStatus struct {
+	Alert     string `json:"alert"`
+	LongBurn  string `json:"longBurn"`
+	ShortBurn string `json:"shortBurn"`
+	Firing    bool   `json:"firing"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Objective",type=string,JSONPath=`.spec.objective`
+//+kubebuilder:printcolumn:name="Window",type=string,JSONPath=`.spec.window`
+//+kubebuilder:printcolumn:name="Availability",type=string,JSONPath=`.status.availability`
+//+kubebuilder:printcolumn:name="Budget Left",type=string,JSONPath=`.status.errorBudgetRemaining`
+//+kubebuilder:printcolumn:name="Alerting",type=string,JSONPath=`.status.conditions[?(@.type=="Alerting")].status`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// EndpointSLO tracks the error budget of an availability objective and alerts on its burn rate
+type EndpointSLO struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   EndpointSLOSpec   `json:"spec,omitempty"`
+	Status EndpointSLOStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+type EndpointSLOList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []EndpointSLO `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&EndpointSLO{}, &EndpointSLOList{})
+}
@@ -0,0 +1,48 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MaintenanceWindowSpec defines when notifications of the selected monitors are muted. Set
+// StartTime/EndTime for a one-off window, or Schedule/Duration for a recurring one.
+type MaintenanceWindowSpec struct {
+	// Selector picks EndpointMonitors in the window's namespace; an empty selector matches all of them
+	Selector metav1.LabelSelector `json:"selector"`
+
+	StartTime *metav1.Time `json:"startTime,omitempty"` // one-off window start
+	EndTime   *metav1.Time `json:"endTime,omitempty"`   // one-off window end
+
+	Schedule string           `json:"schedule,omitempty"` // cron expression for recurring starts, e.g. "0 2 * * SUN"
+	Duration *metav1.Duration `json:"duration,omitempty"` // length of each recurring window, e.g. "2h"
+	TimeZone string           `json:"timeZone,omitempty"` // IANA time zone for the schedule, e.g. "Asia/Kolkata"; defaults to UTC
+
+	Reason string `json:"reason,omitempty"` // shown in the muted monitor's status
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="Schedule",type=string,JSONPath=`.spec.schedule`
+//+kubebuilder:printcolumn:name="Duration",type=string,JSONPath=`.spec.duration`
+//+kubebuilder:printcolumn:name="Start",type=date,JSONPath=`.spec.startTime`
+//+kubebuilder:printcolumn:name="End",type=date,JSONPath=`.spec.endTime`
+//+kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.spec.reason`,priority=1
+
+// MaintenanceWindow mutes notifications of matching EndpointMonitors while checks continue
+type MaintenanceWindow struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec MaintenanceWindowSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+type MaintenanceWindowList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MaintenanceWindow `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&MaintenanceWindow{}, &MaintenanceWindowList{})
+}
@@ -21,9 +21,114 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BurnRateAlert) DeepCopyInto(out *BurnRateAlert) {
+	*out = *in
+	out.LongWindow = in.LongWindow
+	out.ShortWindow = in.ShortWindow
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BurnRateAlert.
+func (in *BurnRateAlert) DeepCopy() *BurnRateAlert {
+	if in == nil {
+		return nil
+	}
+	out := new(BurnRateAlert)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BurnRateStatus) DeepCopyInto(out *BurnRateStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BurnRateStatus.
+func (in *BurnRateStatus) DeepCopy() *BurnRateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BurnRateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CheckRecord) DeepCopyInto(out *CheckRecord) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	out.ResponseTime = in.ResponseTime
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckRecord.
+func (in *CheckRecord) DeepCopy() *CheckRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(CheckRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialsSecretRef) DeepCopyInto(out *CredentialsSecretRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialsSecretRef.
+func (in *CredentialsSecretRef) DeepCopy() *CredentialsSecretRef {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialsSecretRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DnsCheck) DeepCopyInto(out *DnsCheck) {
+	*out = *in
+	if in.Nameservers != nil {
+		in, out := &in.Nameservers, &out.Nameservers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ExpectedAnswers != nil {
+		in, out := &in.ExpectedAnswers, &out.ExpectedAnswers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MinTTL != nil {
+		in, out := &in.MinTTL, &out.MinTTL
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxTTL != nil {
+		in, out := &in.MaxTTL, &out.MaxTTL
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DnsCheck.
+func (in *DnsCheck) DeepCopy() *DnsCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(DnsCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EmailConfig) DeepCopyInto(out *EmailConfig) {
 	*out = *in
@@ -33,6 +138,11 @@ func (in *EmailConfig) DeepCopyInto(out *EmailConfig) {
 		copy(*out, *in)
 	}
 	out.EmailSecretRef = in.EmailSecretRef
+	if in.AlertOn != nil {
+		in, out := &in.AlertOn, &out.AlertOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmailConfig.
@@ -113,6 +223,101 @@ func (in *EndpointMonitorSpec) DeepCopyInto(out *EndpointMonitorSpec) {
 		*out = new(HttpJsonCheck)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PingCheck != nil {
+		in, out := &in.PingCheck, &out.PingCheck
+		*out = new(PingCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DnsCheck != nil {
+		in, out := &in.DnsCheck, &out.DnsCheck
+		*out = new(DnsCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TcpCheck != nil {
+		in, out := &in.TcpCheck, &out.TcpCheck
+		*out = new(TcpCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UdpCheck != nil {
+		in, out := &in.UdpCheck, &out.UdpCheck
+		*out = new(UdpCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PostgresCheck != nil {
+		in, out := &in.PostgresCheck, &out.PostgresCheck
+		*out = new(PostgresCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MysqlCheck != nil {
+		in, out := &in.MysqlCheck, &out.MysqlCheck
+		*out = new(MysqlCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RedisCheck != nil {
+		in, out := &in.RedisCheck, &out.RedisCheck
+		*out = new(RedisCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KafkaCheck != nil {
+		in, out := &in.KafkaCheck, &out.KafkaCheck
+		*out = new(KafkaCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TrinoCheck != nil {
+		in, out := &in.TrinoCheck, &out.TrinoCheck
+		*out = new(TrinoCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OpenSearchCheck != nil {
+		in, out := &in.OpenSearchCheck, &out.OpenSearchCheck
+		*out = new(OpenSearchCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PromqlCheck != nil {
+		in, out := &in.PromqlCheck, &out.PromqlCheck
+		*out = new(PromqlCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KubernetesCheck != nil {
+		in, out := &in.KubernetesCheck, &out.KubernetesCheck
+		*out = new(KubernetesCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Quorum != nil {
+		in, out := &in.Quorum, &out.Quorum
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]MonitorReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.HistoryLimit != nil {
+		in, out := &in.HistoryLimit, &out.HistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.WarningLatency != nil {
+		in, out := &in.WarningLatency, &out.WarningLatency
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.CriticalLatency != nil {
+		in, out := &in.CriticalLatency, &out.CriticalLatency
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointMonitorSpec.
@@ -129,6 +334,31 @@ func (in *EndpointMonitorSpec) DeepCopy() *EndpointMonitorSpec {
 func (in *EndpointMonitorStatus) DeepCopyInto(out *EndpointMonitorStatus) {
 	*out = *in
 	in.LastCheckedTime.DeepCopyInto(&out.LastCheckedTime)
+	out.LastResponseTime = in.LastResponseTime
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]EndpointStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]CheckRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Uptime != nil {
+		in, out := &in.Uptime, &out.Uptime
+		*out = new(UptimeStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointMonitorStatus.
@@ -141,6 +371,150 @@ func (in *EndpointMonitorStatus) DeepCopy() *EndpointMonitorStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EndpointSLO) DeepCopyInto(out *EndpointSLO) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointSLO.
+func (in *EndpointSLO) DeepCopy() *EndpointSLO {
+	if in == nil {
+		return nil
+	}
+	out := new(EndpointSLO)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EndpointSLO) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EndpointSLOList) DeepCopyInto(out *EndpointSLOList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]EndpointSLO, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointSLOList.
+func (in *EndpointSLOList) DeepCopy() *EndpointSLOList {
+	if in == nil {
+		return nil
+	}
+	out := new(EndpointSLOList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EndpointSLOList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EndpointSLOSpec) DeepCopyInto(out *EndpointSLOSpec) {
+	*out = *in
+	if in.Monitors != nil {
+		in, out := &in.Monitors, &out.Monitors
+		*out = make([]MonitorReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Window != nil {
+		in, out := &in.Window, &out.Window
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	in.Notify.DeepCopyInto(&out.Notify)
+	if in.Alerts != nil {
+		in, out := &in.Alerts, &out.Alerts
+		*out = make([]BurnRateAlert, len(*in))
+		copy(*out, *in)
+	}
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointSLOSpec.
+func (in *EndpointSLOSpec) DeepCopy() *EndpointSLOSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EndpointSLOSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EndpointSLOStatus) DeepCopyInto(out *EndpointSLOStatus) {
+	*out = *in
+	in.LastEvaluatedTime.DeepCopyInto(&out.LastEvaluatedTime)
+	if in.BurnRates != nil {
+		in, out := &in.BurnRates, &out.BurnRates
+		*out = make([]BurnRateStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.FiringAlerts != nil {
+		in, out := &in.FiringAlerts, &out.FiringAlerts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointSLOStatus.
+func (in *EndpointSLOStatus) DeepCopy() *EndpointSLOStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EndpointSLOStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EndpointStatus) DeepCopyInto(out *EndpointStatus) {
+	*out = *in
+	out.ResponseTime = in.ResponseTime
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointStatus.
+func (in *EndpointStatus) DeepCopy() *EndpointStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EndpointStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HttpJsonCheck) DeepCopyInto(out *HttpJsonCheck) {
 	*out = *in
@@ -151,6 +525,13 @@ func (in *HttpJsonCheck) DeepCopyInto(out *HttpJsonCheck) {
 			(*out)[key] = val
 		}
 	}
+	if in.Assertions != nil {
+		in, out := &in.Assertions, &out.Assertions
+		*out = make([]JsonAssertion, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HttpJsonCheck.
@@ -164,61 +545,729 @@ func (in *HttpJsonCheck) DeepCopy() *HttpJsonCheck {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NotifyConfig) DeepCopyInto(out *NotifyConfig) {
+func (in *JsonAssertion) DeepCopyInto(out *JsonAssertion) {
 	*out = *in
-	if in.Slack != nil {
-		in, out := &in.Slack, &out.Slack
-		*out = new(SlackConfig)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Email != nil {
-		in, out := &in.Email, &out.Email
-		*out = new(EmailConfig)
-		(*in).DeepCopyInto(*out)
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotifyConfig.
-func (in *NotifyConfig) DeepCopy() *NotifyConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JsonAssertion.
+func (in *JsonAssertion) DeepCopy() *JsonAssertion {
 	if in == nil {
 		return nil
 	}
-	out := new(NotifyConfig)
+	out := new(JsonAssertion)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecretRef) DeepCopyInto(out *SecretRef) {
+func (in *KafkaCheck) DeepCopyInto(out *KafkaCheck) {
 	*out = *in
+	if in.Topics != nil {
+		in, out := &in.Topics, &out.Topics
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MinBrokers != nil {
+		in, out := &in.MinBrokers, &out.MinBrokers
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SASL != nil {
+		in, out := &in.SASL, &out.SASL
+		*out = new(KafkaSASL)
+		**out = **in
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(TLSConfig)
+		**out = **in
+	}
+	if in.TLSSecretRef != nil {
+		in, out := &in.TLSSecretRef, &out.TLSSecretRef
+		*out = new(TLSSecretRef)
+		**out = **in
+	}
+	if in.ConsumerGroups != nil {
+		in, out := &in.ConsumerGroups, &out.ConsumerGroups
+		*out = make([]KafkaConsumerGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretRef.
-func (in *SecretRef) DeepCopy() *SecretRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaCheck.
+func (in *KafkaCheck) DeepCopy() *KafkaCheck {
 	if in == nil {
 		return nil
 	}
-	out := new(SecretRef)
+	out := new(KafkaCheck)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SlackConfig) DeepCopyInto(out *SlackConfig) {
+func (in *KafkaConsumerGroup) DeepCopyInto(out *KafkaConsumerGroup) {
 	*out = *in
-	if in.AlertOn != nil {
-		in, out := &in.AlertOn, &out.AlertOn
+	if in.Topics != nil {
+		in, out := &in.Topics, &out.Topics
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlackConfig.
-func (in *SlackConfig) DeepCopy() *SlackConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaConsumerGroup.
+func (in *KafkaConsumerGroup) DeepCopy() *KafkaConsumerGroup {
 	if in == nil {
 		return nil
 	}
-	out := new(SlackConfig)
+	out := new(KafkaConsumerGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaSASL) DeepCopyInto(out *KafkaSASL) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaSASL.
+func (in *KafkaSASL) DeepCopy() *KafkaSASL {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaSASL)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesCheck) DeepCopyInto(out *KubernetesCheck) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MinReady != nil {
+		in, out := &in.MinReady, &out.MinReady
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxRestarts != nil {
+		in, out := &in.MaxRestarts, &out.MaxRestarts
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesCheck.
+func (in *KubernetesCheck) DeepCopy() *KubernetesCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MaintenanceWindow) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindowList) DeepCopyInto(out *MaintenanceWindowList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MaintenanceWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindowList.
+func (in *MaintenanceWindowList) DeepCopy() *MaintenanceWindowList {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindowList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MaintenanceWindowList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindowSpec) DeepCopyInto(out *MaintenanceWindowSpec) {
+	*out = *in
+	in.Selector.DeepCopyInto(&out.Selector)
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.EndTime != nil {
+		in, out := &in.EndTime, &out.EndTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindowSpec.
+func (in *MaintenanceWindowSpec) DeepCopy() *MaintenanceWindowSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindowSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonitorReference) DeepCopyInto(out *MonitorReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitorReference.
+func (in *MonitorReference) DeepCopy() *MonitorReference {
+	if in == nil {
+		return nil
+	}
+	out := new(MonitorReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MysqlCheck) DeepCopyInto(out *MysqlCheck) {
+	*out = *in
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(CredentialsSecretRef)
+		**out = **in
+	}
+	if in.MaxReplicationLag != nil {
+		in, out := &in.MaxReplicationLag, &out.MaxReplicationLag
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ConnectTimeout != nil {
+		in, out := &in.ConnectTimeout, &out.ConnectTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MysqlCheck.
+func (in *MysqlCheck) DeepCopy() *MysqlCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(MysqlCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotifyConfig) DeepCopyInto(out *NotifyConfig) {
+	*out = *in
+	if in.Slack != nil {
+		in, out := &in.Slack, &out.Slack
+		*out = new(SlackConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Email != nil {
+		in, out := &in.Email, &out.Email
+		*out = new(EmailConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotifyConfig.
+func (in *NotifyConfig) DeepCopy() *NotifyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NotifyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchCheck) DeepCopyInto(out *OpenSearchCheck) {
+	*out = *in
+	if in.AcceptedStatuses != nil {
+		in, out := &in.AcceptedStatuses, &out.AcceptedStatuses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DegradedStatuses != nil {
+		in, out := &in.DegradedStatuses, &out.DegradedStatuses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MinNodes != nil {
+		in, out := &in.MinNodes, &out.MinNodes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MinDataNodes != nil {
+		in, out := &in.MinDataNodes, &out.MinDataNodes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxUnassignedShards != nil {
+		in, out := &in.MaxUnassignedShards, &out.MaxUnassignedShards
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxPendingTasks != nil {
+		in, out := &in.MaxPendingTasks, &out.MaxPendingTasks
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MinActiveShardsPercent != nil {
+		in, out := &in.MinActiveShardsPercent, &out.MinActiveShardsPercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Indices != nil {
+		in, out := &in.Indices, &out.Indices
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(CredentialsSecretRef)
+		**out = **in
+	}
+	if in.APIKeySecretRef != nil {
+		in, out := &in.APIKeySecretRef, &out.APIKeySecretRef
+		*out = new(SecretKeyRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchCheck.
+func (in *OpenSearchCheck) DeepCopy() *OpenSearchCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PingCheck) DeepCopyInto(out *PingCheck) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxPacketLoss != nil {
+		in, out := &in.MaxPacketLoss, &out.MaxPacketLoss
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingCheck.
+func (in *PingCheck) DeepCopy() *PingCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(PingCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresCheck) DeepCopyInto(out *PostgresCheck) {
+	*out = *in
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(CredentialsSecretRef)
+		**out = **in
+	}
+	if in.MaxReplicationLag != nil {
+		in, out := &in.MaxReplicationLag, &out.MaxReplicationLag
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ConnectTimeout != nil {
+		in, out := &in.ConnectTimeout, &out.ConnectTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresCheck.
+func (in *PostgresCheck) DeepCopy() *PostgresCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PromqlCheck) DeepCopyInto(out *PromqlCheck) {
+	*out = *in
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(CredentialsSecretRef)
+		**out = **in
+	}
+	if in.BearerTokenSecretRef != nil {
+		in, out := &in.BearerTokenSecretRef, &out.BearerTokenSecretRef
+		*out = new(SecretKeyRef)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PromqlCheck.
+func (in *PromqlCheck) DeepCopy() *PromqlCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(PromqlCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedisCheck) DeepCopyInto(out *RedisCheck) {
+	*out = *in
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(CredentialsSecretRef)
+		**out = **in
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(TLSConfig)
+		**out = **in
+	}
+	if in.MaxMemoryPercent != nil {
+		in, out := &in.MaxMemoryPercent, &out.MaxMemoryPercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxUsedMemory != nil {
+		in, out := &in.MaxUsedMemory, &out.MaxUsedMemory
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedisCheck.
+func (in *RedisCheck) DeepCopy() *RedisCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(RedisCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretKeyRef) DeepCopyInto(out *SecretKeyRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretKeyRef.
+func (in *SecretKeyRef) DeepCopy() *SecretKeyRef {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretKeyRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretRef) DeepCopyInto(out *SecretRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretRef.
+func (in *SecretRef) DeepCopy() *SecretRef {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlackConfig) DeepCopyInto(out *SlackConfig) {
+	*out = *in
+	if in.AlertOn != nil {
+		in, out := &in.AlertOn, &out.AlertOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlackConfig.
+func (in *SlackConfig) DeepCopy() *SlackConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SlackConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLSConfig) DeepCopyInto(out *TLSConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSConfig.
+func (in *TLSConfig) DeepCopy() *TLSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLSSecretRef) DeepCopyInto(out *TLSSecretRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSSecretRef.
+func (in *TLSSecretRef) DeepCopy() *TLSSecretRef {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSSecretRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TcpCheck) DeepCopyInto(out *TcpCheck) {
+	*out = *in
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]TcpStep, len(*in))
+		copy(*out, *in)
+	}
+	if in.ConnectTimeout != nil {
+		in, out := &in.ConnectTimeout, &out.ConnectTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ReadTimeout != nil {
+		in, out := &in.ReadTimeout, &out.ReadTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(TLSConfig)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TcpCheck.
+func (in *TcpCheck) DeepCopy() *TcpCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(TcpCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TcpStep) DeepCopyInto(out *TcpStep) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TcpStep.
+func (in *TcpStep) DeepCopy() *TcpStep {
+	if in == nil {
+		return nil
+	}
+	out := new(TcpStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrinoCheck) DeepCopyInto(out *TrinoCheck) {
+	*out = *in
+	if in.MinActiveWorkers != nil {
+		in, out := &in.MinActiveWorkers, &out.MinActiveWorkers
+		*out = new(int32)
+		**out = **in
+	}
+	if in.WarningQueryTime != nil {
+		in, out := &in.WarningQueryTime, &out.WarningQueryTime
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.CriticalQueryTime != nil {
+		in, out := &in.CriticalQueryTime, &out.CriticalQueryTime
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrinoCheck.
+func (in *TrinoCheck) DeepCopy() *TrinoCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(TrinoCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UdpCheck) DeepCopyInto(out *UdpCheck) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Retries != nil {
+		in, out := &in.Retries, &out.Retries
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UdpCheck.
+func (in *UdpCheck) DeepCopy() *UdpCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(UdpCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UptimeBucket) DeepCopyInto(out *UptimeBucket) {
+	*out = *in
+	in.Hour.DeepCopyInto(&out.Hour)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UptimeBucket.
+func (in *UptimeBucket) DeepCopy() *UptimeBucket {
+	if in == nil {
+		return nil
+	}
+	out := new(UptimeBucket)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UptimeStatus) DeepCopyInto(out *UptimeStatus) {
+	*out = *in
+	if in.AverageLatency != nil {
+		in, out := &in.AverageLatency, &out.AverageLatency
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.P95Latency != nil {
+		in, out := &in.P95Latency, &out.P95Latency
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.HourlyBuckets != nil {
+		in, out := &in.HourlyBuckets, &out.HourlyBuckets
+		*out = make([]UptimeBucket, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UptimeStatus.
+func (in *UptimeStatus) DeepCopy() *UptimeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(UptimeStatus)
 	in.DeepCopyInto(out)
 	return out
 }
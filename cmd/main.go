@@ -19,6 +19,7 @@ package main
 import (
 	"crypto/tls"
 	"flag"
+	"net/http"
 	"os"
 	"path/filepath"
 
@@ -26,19 +27,23 @@ import (
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	monitoringv1alpha1 "github.com/LiciousTech/endpoint-monitoring-operator/api/v1alpha1"
 	"github.com/LiciousTech/endpoint-monitoring-operator/internal/controller"
+	"github.com/LiciousTech/endpoint-monitoring-operator/internal/store"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -50,6 +55,8 @@ var (
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
+	utilruntime.Must(gatewayv1.Install(scheme))
+
 	utilruntime.Must(monitoringv1alpha1.AddToScheme(scheme))
 	// +kubebuilder:scaffold:scheme
 }
@@ -63,6 +70,11 @@ func main() {
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var enableDiscovery bool
+	var discoveryWebhookURL string
+	var historyPath string
+	var historyOpts store.Options
+	var allowCrossNamespaceTargets bool
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -81,6 +93,21 @@ func main() {
 	flag.StringVar(&metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.BoolVar(&enableDiscovery, "enable-discovery", false,
+		"If set, EndpointMonitors are created for Services, Ingresses and HTTPRoutes annotated with "+
+			"endpointmonitor.licious.io/driver.")
+	flag.StringVar(&discoveryWebhookURL, "discovery-slack-webhook-url", os.Getenv("DISCOVERY_SLACK_WEBHOOK_URL"),
+		"The Slack webhook used by discovered monitors. Defaults to $DISCOVERY_SLACK_WEBHOOK_URL.")
+	flag.StringVar(&historyPath, "history-path", "",
+		"If set, every check result is persisted in a bbolt database at this path, e.g. on a PersistentVolume, "+
+			"and served under /history on the metrics endpoint.")
+	flag.DurationVar(&historyOpts.Retention, "history-retention", store.DefaultRetention,
+		"How long hourly rollups of the persisted check results are kept.")
+	flag.DurationVar(&historyOpts.RawRetention, "history-raw-retention", store.DefaultRawRetention,
+		"How long individual persisted check results are kept before only their hourly rollups remain.")
+	flag.BoolVar(&allowCrossNamespaceTargets, "allow-cross-namespace-targets", false,
+		"If set, kubernetes checks may target workloads outside the monitor's namespace through "+
+			"kubernetesCheck.namespace.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -143,6 +170,17 @@ func main() {
 		TLSOpts:       tlsOpts,
 	}
 
+	var historyStore *store.Store
+	if historyPath != "" {
+		var err error
+		historyStore, err = store.Open(historyPath, historyOpts)
+		if err != nil {
+			setupLog.Error(err, "unable to open check history")
+			os.Exit(1)
+		}
+		metricsServerOptions.ExtraHandlers = map[string]http.Handler{store.HandlerPath: historyStore.Handler()}
+	}
+
 	if secureMetrics {
 		// FilterProvider is used to protect the metrics endpoint with authn/authz.
 		// These configurations ensure that only authorized users and service accounts
@@ -185,6 +223,10 @@ func main() {
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "0ef8453e.licious.app",
+		// Credentials are read on demand rather than caching every Secret in the cluster
+		Client: client.Options{
+			Cache: &client.CacheOptions{DisableFor: []client.Object{&corev1.Secret{}}},
+		},
 		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
 		// when the Manager ends. This requires the binary to immediately end when the
 		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
@@ -202,13 +244,47 @@ func main() {
 		os.Exit(1)
 	}
 
+	if historyStore != nil {
+		// The manager prunes the history while it runs and closes it on shutdown
+		if err := mgr.Add(historyStore); err != nil {
+			setupLog.Error(err, "unable to add check history to manager")
+			os.Exit(1)
+		}
+	}
+
 	if err = (&controller.EndpointMonitorReconciler{
+		Client:                     mgr.GetClient(),
+		Scheme:                     mgr.GetScheme(),
+		Store:                      historyStore,
+		AllowCrossNamespaceTargets: allowCrossNamespaceTargets,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "EndpointMonitor")
+		os.Exit(1)
+	}
+	if err = (&controller.EndpointSLOReconciler{
 		Client: mgr.GetClient(),
 		Scheme: mgr.GetScheme(),
+		Store:  historyStore,
 	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "EndpointMonitor")
+		setupLog.Error(err, "unable to create controller", "controller", "EndpointSLO")
 		os.Exit(1)
 	}
+	if enableDiscovery {
+		if discoveryWebhookURL == "" {
+			setupLog.Error(nil, "--discovery-slack-webhook-url is required when discovery is enabled")
+			os.Exit(1)
+		}
+		if err = (&controller.DiscoveryReconciler{
+			Client: mgr.GetClient(),
+			Scheme: mgr.GetScheme(),
+			Notify: monitoringv1alpha1.NotifyConfig{
+				Slack: &monitoringv1alpha1.SlackConfig{Enabled: true, WebhookURL: discoveryWebhookURL},
+			},
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "Discovery")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
 	if metricsCertWatcher != nil {
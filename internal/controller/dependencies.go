@@ -0,0 +1,122 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	monitorv1alpha1 "github.com/LiciousTech/endpoint-monitoring-operator/api/v1alpha1"
+	"github.com/LiciousTech/endpoint-monitoring-operator/internal/driver"
+)
+
+// ConditionSuppressed is true while a failing dependency holds back the monitor's notifications
+const ConditionSuppressed = "Suppressed"
+
+// maxListedDependents bounds the dependents named in a root cause alert
+const maxListedDependents = 10
+
+// rootCauses describes every dependency of monitor whose last check failed. Missing
+// dependencies are logged and ignored so a typo cannot silence a monitor forever.
+func (r *EndpointMonitorReconciler) rootCauses(ctx context.Context, monitor *monitorv1alpha1.EndpointMonitor) ([]string, error) {
+	logger := log.FromContext(ctx)
+
+	var causes []string
+	for _, ref := range monitor.Spec.DependsOn {
+		key := dependencyKey(monitor, ref)
+		if key.Namespace == monitor.Namespace && key.Name == monitor.Name {
+			continue
+		}
+
+		var dependency monitorv1alpha1.EndpointMonitor
+		if err := r.Get(ctx, key, &dependency); err != nil {
+			if errors.IsNotFound(err) {
+				logger.Info("Dependency not found, ignoring", "dependency", key.String())
+				continue
+			}
+			return nil, err
+		}
+		if dependency.Status.LastStatus != driver.StatusFailure {
+			continue
+		}
+
+		cause := fmt.Sprintf("%s is %s", key, dependency.Status.LastStatus)
+		// A dependency that is itself suppressed points further up the chain
+		if cond := meta.FindStatusCondition(dependency.Status.Conditions, ConditionSuppressed); cond != nil && cond.Status == metav1.ConditionTrue {
+			cause = fmt.Sprintf("%s (suppressed by %s)", cause, cond.Message)
+		}
+		causes = append(causes, cause)
+	}
+	return causes, nil
+}
+
+// dependents lists the monitors, in any namespace, that declare monitor in their dependsOn
+func (r *EndpointMonitorReconciler) dependents(ctx context.Context, monitor *monitorv1alpha1.EndpointMonitor) ([]string, error) {
+	var monitors monitorv1alpha1.EndpointMonitorList
+	if err := r.List(ctx, &monitors); err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for i := range monitors.Items {
+		candidate := &monitors.Items[i]
+		for _, ref := range candidate.Spec.DependsOn {
+			if key := dependencyKey(candidate, ref); key.Namespace == monitor.Namespace && key.Name == monitor.Name {
+				names = append(names, types.NamespacedName{Namespace: candidate.Namespace, Name: candidate.Name}.String())
+				break
+			}
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// setSuppressed records whether notifications were suppressed and why. Monitors without
+// dependencies carry no Suppressed condition at all.
+func setSuppressed(monitor *monitorv1alpha1.EndpointMonitor, causes []string) {
+	if len(monitor.Spec.DependsOn) == 0 {
+		meta.RemoveStatusCondition(&monitor.Status.Conditions, ConditionSuppressed)
+		return
+	}
+
+	cond := metav1.Condition{
+		Type:               ConditionSuppressed,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: monitor.Generation,
+		Reason:             "DependenciesHealthy",
+		Message:            "No dependency is failing",
+	}
+	if len(causes) > 0 {
+		cond.Status = metav1.ConditionTrue
+		cond.Reason = "DependencyFailing"
+		cond.Message = truncate(strings.Join(causes, "; "), maxStatusMessageLength)
+	}
+	meta.SetStatusCondition(&monitor.Status.Conditions, cond)
+}
+
+// describeDependents renders the dependents appended to a root cause alert
+func describeDependents(names []string) string {
+	listed := names
+	if len(listed) > maxListedDependents {
+		listed = listed[:maxListedDependents]
+	}
+	text := strings.Join(listed, ", ")
+	if more := len(names) - len(listed); more > 0 {
+		text += fmt.Sprintf(" and %d more", more)
+	}
+	return fmt.Sprintf("Affected dependents (%d): %s", len(names), text)
+}
+
+func dependencyKey(monitor *monitorv1alpha1.EndpointMonitor, ref monitorv1alpha1.MonitorReference) types.NamespacedName {
+	namespace := ref.Namespace
+	if namespace == "" {
+		namespace = monitor.Namespace
+	}
+	return types.NamespacedName{Namespace: namespace, Name: ref.Name}
+}
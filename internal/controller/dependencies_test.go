@@ -0,0 +1,85 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	Endpointmonitoringv1alpha1 "github.com/LiciousTech/endpoint-monitoring-operator/api/v1alpha1"
+	"github.com/LiciousTech/endpoint-monitoring-operator/internal/driver"
+)
+
+var _ = Describe("Monitor dependencies", func() {
+	ctx := context.Background()
+
+	newMonitor := func(name string, dependsOn ...string) *Endpointmonitoringv1alpha1.EndpointMonitor {
+		monitor := &Endpointmonitoringv1alpha1.EndpointMonitor{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: Endpointmonitoringv1alpha1.EndpointMonitorSpec{
+				Driver:        "http",
+				Endpoint:      "http://" + name + ".default.svc",
+				CheckInterval: 60,
+			},
+		}
+		for _, dependency := range dependsOn {
+			monitor.Spec.DependsOn = append(monitor.Spec.DependsOn, Endpointmonitoringv1alpha1.MonitorReference{Name: dependency})
+		}
+		return monitor
+	}
+
+	var root, checkout, search *Endpointmonitoringv1alpha1.EndpointMonitor
+
+	BeforeEach(func() {
+		root = newMonitor("shared-opensearch")
+		checkout = newMonitor("checkout", "shared-opensearch")
+		search = newMonitor("search", "shared-opensearch", "missing")
+		for _, monitor := range []*Endpointmonitoringv1alpha1.EndpointMonitor{root, checkout, search} {
+			Expect(k8sClient.Create(ctx, monitor)).To(Succeed())
+		}
+	})
+
+	AfterEach(func() {
+		for _, monitor := range []*Endpointmonitoringv1alpha1.EndpointMonitor{root, checkout, search} {
+			Expect(k8sClient.Delete(ctx, monitor)).To(Succeed())
+		}
+	})
+
+	It("should suppress dependents of a failing monitor and list them on the root", func() {
+		reconciler := &EndpointMonitorReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+
+		causes, err := reconciler.rootCauses(ctx, checkout)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(causes).To(BeEmpty())
+
+		By("marking the shared dependency as failing")
+		root.Status.LastStatus = driver.StatusFailure
+		Expect(k8sClient.Status().Update(ctx, root)).To(Succeed())
+
+		causes, err = reconciler.rootCauses(ctx, search)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(causes).To(Equal([]string{"default/shared-opensearch is failure"}))
+
+		setSuppressed(search, causes)
+		cond := meta.FindStatusCondition(search.Status.Conditions, ConditionSuppressed)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+		Expect(cond.Message).To(Equal("default/shared-opensearch is failure"))
+
+		dependents, err := reconciler.dependents(ctx, root)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(dependents).To(Equal([]string{"default/checkout", "default/search"}))
+	})
+
+	It("should cap the dependents named in an alert", func() {
+		var names []string
+		for i := range 12 {
+			names = append(names, fmt.Sprintf("default/service-%02d", i))
+		}
+		Expect(describeDependents(names)).To(HavePrefix("Affected dependents (12): default/service-00, "))
+		Expect(describeDependents(names)).To(HaveSuffix("default/service-09 and 2 more"))
+	})
+})
@@ -0,0 +1,383 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	monitorv1alpha1 "github.com/LiciousTech/endpoint-monitoring-operator/api/v1alpha1"
+)
+
+// Annotations read from Services, Ingresses and HTTPRoutes. The driver annotation opts an
+// object into discovery; the others are optional.
+const (
+	AnnotationDriver   = "endpointmonitor.licious.io/driver"   // e.g. "http", "tcp" or "kubernetes"
+	AnnotationPath     = "endpointmonitor.licious.io/path"     // appended to HTTP endpoints, e.g. "/healthz"
+	AnnotationInterval = "endpointmonitor.licious.io/interval" // seconds or a duration such as "30s"
+	AnnotationChannel  = "endpointmonitor.licious.io/channel"  // Slack channel for the alerts
+	AnnotationPort     = "endpointmonitor.licious.io/port"     // Service port name or number, defaults to the first port
+
+	// LabelDiscovered marks EndpointMonitors created by the discovery controller
+	LabelDiscovered = "endpointmonitor.licious.io/discovered"
+
+	defaultDiscoveryInterval = time.Minute
+)
+
+// DiscoveryReconciler keeps one EndpointMonitor per annotated Service, Ingress or HTTPRoute.
+// Monitors are owned by their source object, so deleting the source deletes the monitor and
+// removing the driver annotation garbage-collects it.
+type DiscoveryReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	// Notify is copied into every discovered monitor; the channel annotation overrides the Slack channel
+	Notify monitorv1alpha1.NotifyConfig
+}
+
+// discoverySource describes one kind of object watched for annotations
+type discoverySource struct {
+	kind      string
+	prefix    string // prepended to the source name to form the monitor name
+	newObject func() client.Object
+	target    func(obj client.Object) (*discoveryTarget, error)
+}
+
+// discoveryTarget is where a source object can be reached, before it is shaped into a driver endpoint
+type discoveryTarget struct {
+	scheme        string // http or https
+	host          string
+	port          int32  // 0 means the scheme's default port
+	kubernetesRef string // endpoint for the kubernetes driver, only set for Services
+}
+
+// sourceReconciler reconciles the monitors of a single source kind
+type sourceReconciler struct {
+	*DiscoveryReconciler
+	source discoverySource
+}
+
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch
+
+func (r *sourceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	obj := r.source.newObject()
+	if err := r.Get(ctx, req.NamespacedName, obj); err != nil {
+		// Monitors of deleted objects are removed through their owner references
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	var desired *monitorv1alpha1.EndpointMonitor
+	if obj.GetDeletionTimestamp().IsZero() && obj.GetAnnotations()[AnnotationDriver] != "" {
+		monitor, err := r.desiredMonitor(obj)
+		if err != nil {
+			// Invalid annotations keep any existing monitor rather than silently dropping coverage
+			logger.Error(err, "Skipping discovery for annotated object", "kind", r.source.kind)
+			return ctrl.Result{}, nil
+		}
+		desired = monitor
+	}
+
+	if desired != nil {
+		monitor := &monitorv1alpha1.EndpointMonitor{
+			ObjectMeta: metav1.ObjectMeta{Name: desired.Name, Namespace: desired.Namespace},
+		}
+		op, err := controllerutil.CreateOrUpdate(ctx, r.Client, monitor, func() error {
+			if !monitor.CreationTimestamp.IsZero() && !metav1.IsControlledBy(monitor, obj) {
+				return fmt.Errorf("EndpointMonitor %s already exists and is not managed by discovery", monitor.Name)
+			}
+			if monitor.Labels == nil {
+				monitor.Labels = map[string]string{}
+			}
+			monitor.Labels[LabelDiscovered] = "true"
+			// Suspending a discovered monitor is the one spec change that survives reconciliation
+			suspend := monitor.Spec.Suspend
+			monitor.Spec = desired.Spec
+			monitor.Spec.Suspend = suspend
+			return controllerutil.SetControllerReference(obj, monitor, r.Scheme)
+		})
+		if err != nil {
+			logger.Error(err, "Failed to apply discovered EndpointMonitor", "monitor", desired.Name)
+			return ctrl.Result{}, err
+		}
+		if op != controllerutil.OperationResultNone {
+			logger.Info("Discovered EndpointMonitor "+string(op),
+				"kind", r.source.kind,
+				"monitor", monitor.Name,
+				"driver", monitor.Spec.Driver,
+				"endpoint", monitor.Spec.Endpoint)
+		}
+	}
+
+	// Remove monitors this object no longer asks for, e.g. after the driver annotation was dropped
+	var owned monitorv1alpha1.EndpointMonitorList
+	if err := r.List(ctx, &owned, client.InNamespace(req.Namespace), client.MatchingLabels{LabelDiscovered: "true"}); err != nil {
+		logger.Error(err, "Failed to list discovered EndpointMonitors")
+		return ctrl.Result{}, err
+	}
+	for i := range owned.Items {
+		monitor := &owned.Items[i]
+		if !metav1.IsControlledBy(monitor, obj) || (desired != nil && monitor.Name == desired.Name) {
+			continue
+		}
+		if err := r.Delete(ctx, monitor); client.IgnoreNotFound(err) != nil {
+			logger.Error(err, "Failed to delete discovered EndpointMonitor", "monitor", monitor.Name)
+			return ctrl.Result{}, err
+		}
+		logger.Info("Discovered EndpointMonitor deleted", "kind", r.source.kind, "monitor", monitor.Name)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// desiredMonitor builds the EndpointMonitor described by the annotations of obj
+func (r *sourceReconciler) desiredMonitor(obj client.Object) (*monitorv1alpha1.EndpointMonitor, error) {
+	annotations := obj.GetAnnotations()
+	driverType := annotations[AnnotationDriver]
+
+	target, err := r.source.target(obj)
+	if err != nil {
+		return nil, err
+	}
+	endpoint, err := discoveryEndpoint(driverType, target, annotations[AnnotationPath])
+	if err != nil {
+		return nil, err
+	}
+
+	interval := defaultDiscoveryInterval
+	if value := annotations[AnnotationInterval]; value != "" {
+		if interval, err = parseDiscoveryInterval(value); err != nil {
+			return nil, err
+		}
+	}
+
+	notify := *r.Notify.DeepCopy()
+	if channel := annotations[AnnotationChannel]; channel != "" && notify.Slack != nil {
+		notify.Slack.Channel = channel
+	}
+
+	return &monitorv1alpha1.EndpointMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      discoveredMonitorName(r.source.prefix, obj.GetName()),
+			Namespace: obj.GetNamespace(),
+		},
+		Spec: monitorv1alpha1.EndpointMonitorSpec{
+			Driver:   driverType,
+			Endpoint: endpoint,
+			Interval: &metav1.Duration{Duration: interval},
+			Notify:   notify,
+		},
+	}, nil
+}
+
+// discoveredMonitorName prefixes the source name. Ingress and HTTPRoute names may already use
+// the whole 253 characters of an object name, so names that would be too long are truncated
+// and keep a hash of the source name to stay unique.
+func discoveredMonitorName(prefix, name string) string {
+	full := prefix + "-" + name
+	if len(full) <= validation.DNS1123SubdomainMaxLength {
+		return full
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(name))
+	suffix := fmt.Sprintf("-%08x", h.Sum32())
+	// Trim separators left at the cut so the name still ends with an alphanumeric character
+	head := strings.TrimRight(full[:validation.DNS1123SubdomainMaxLength-len(suffix)], "-.")
+	return head + suffix
+}
+
+// discoveryEndpoint shapes a target into the endpoint format the driver expects
+func discoveryEndpoint(driverType string, target *discoveryTarget, path string) (string, error) {
+	hostPort := target.host
+	if target.port != 0 {
+		hostPort = net.JoinHostPort(target.host, strconv.Itoa(int(target.port)))
+	}
+
+	switch driverType {
+	case "http", "http-json":
+		if path != "" && !strings.HasPrefix(path, "/") {
+			path = "/" + path
+		}
+		return target.scheme + "://" + hostPort + path, nil
+	case "tcp":
+		port := target.port
+		if port == 0 {
+			port = 80
+			if target.scheme == "https" {
+				port = 443
+			}
+		}
+		return net.JoinHostPort(target.host, strconv.Itoa(int(port))), nil
+	case "dns", "ping":
+		return target.host, nil
+	case "kubernetes":
+		if target.kubernetesRef == "" {
+			return "", fmt.Errorf("the kubernetes driver is only supported on Services")
+		}
+		return target.kubernetesRef, nil
+	default:
+		return "", fmt.Errorf("driver %q is not supported by discovery, expected http, http-json, tcp, dns, ping or kubernetes", driverType)
+	}
+}
+
+// parseDiscoveryInterval accepts whole seconds ("30") or a duration ("1m")
+func parseDiscoveryInterval(value string) (time.Duration, error) {
+	interval, err := time.ParseDuration(value)
+	if seconds, atoiErr := strconv.Atoi(value); atoiErr == nil {
+		interval, err = time.Duration(seconds)*time.Second, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s annotation %q", AnnotationInterval, value)
+	}
+	if interval <= 0 {
+		return 0, fmt.Errorf("invalid %s annotation %q, must be positive", AnnotationInterval, value)
+	}
+	return interval, nil
+}
+
+// serviceTarget addresses a Service through its cluster DNS name
+func serviceTarget(obj client.Object) (*discoveryTarget, error) {
+	svc := obj.(*corev1.Service)
+	if len(svc.Spec.Ports) == 0 {
+		return nil, fmt.Errorf("service %s has no ports", svc.Name)
+	}
+
+	port := svc.Spec.Ports[0]
+	if want := svc.Annotations[AnnotationPort]; want != "" {
+		i := slices.IndexFunc(svc.Spec.Ports, func(p corev1.ServicePort) bool {
+			return p.Name == want || strconv.Itoa(int(p.Port)) == want
+		})
+		if i < 0 {
+			return nil, fmt.Errorf("service %s has no port %q", svc.Name, want)
+		}
+		port = svc.Spec.Ports[i]
+	}
+
+	scheme := "http"
+	if port.Port == 443 || port.Name == "https" || strings.HasPrefix(port.Name, "https-") {
+		scheme = "https"
+	}
+	return &discoveryTarget{
+		scheme:        scheme,
+		host:          fmt.Sprintf("%s.%s.svc", svc.Name, svc.Namespace),
+		port:          port.Port,
+		kubernetesRef: "service/" + svc.Name,
+	}, nil
+}
+
+// ingressTarget uses the first rule host, or the load balancer address for host-less Ingresses
+func ingressTarget(obj client.Object) (*discoveryTarget, error) {
+	ing := obj.(*networkingv1.Ingress)
+
+	var host string
+	for _, rule := range ing.Spec.Rules {
+		if rule.Host != "" && !strings.HasPrefix(rule.Host, "*") {
+			host = rule.Host
+			break
+		}
+	}
+	if host == "" {
+		for _, lb := range ing.Status.LoadBalancer.Ingress {
+			if host = lb.Hostname; host == "" {
+				host = lb.IP
+			}
+			if host != "" {
+				break
+			}
+		}
+	}
+	if host == "" {
+		return nil, fmt.Errorf("ingress %s has no host and no load balancer address yet", ing.Name)
+	}
+
+	scheme := "http"
+	for _, tls := range ing.Spec.TLS {
+		if slices.Contains(tls.Hosts, host) {
+			scheme = "https"
+			break
+		}
+	}
+	return &discoveryTarget{scheme: scheme, host: host}, nil
+}
+
+// httpRouteTarget uses the first non-wildcard hostname; routes are assumed to be served over HTTPS
+func httpRouteTarget(obj client.Object) (*discoveryTarget, error) {
+	route := obj.(*gatewayv1.HTTPRoute)
+	for _, hostname := range route.Spec.Hostnames {
+		if !strings.HasPrefix(string(hostname), "*") {
+			return &discoveryTarget{scheme: "https", host: string(hostname)}, nil
+		}
+	}
+	return nil, fmt.Errorf("httproute %s has no non-wildcard hostname", route.Name)
+}
+
+// hasDriverAnnotation lets through annotated objects and updates that remove the annotation.
+// Deletions need no handling since owner references garbage-collect the monitors.
+var hasDriverAnnotation = predicate.Funcs{
+	CreateFunc: func(e event.CreateEvent) bool {
+		return e.Object.GetAnnotations()[AnnotationDriver] != ""
+	},
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		return e.ObjectOld.GetAnnotations()[AnnotationDriver] != "" || e.ObjectNew.GetAnnotations()[AnnotationDriver] != ""
+	},
+	DeleteFunc: func(event.DeleteEvent) bool {
+		return false
+	},
+	GenericFunc: func(e event.GenericEvent) bool {
+		return e.Object.GetAnnotations()[AnnotationDriver] != ""
+	},
+}
+
+// SetupWithManager starts one controller per source kind. HTTPRoutes are only watched when
+// the Gateway API CRDs are installed.
+func (r *DiscoveryReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	sources := []discoverySource{
+		{kind: "Service", prefix: "svc", newObject: func() client.Object { return &corev1.Service{} }, target: serviceTarget},
+		{kind: "Ingress", prefix: "ing", newObject: func() client.Object { return &networkingv1.Ingress{} }, target: ingressTarget},
+	}
+
+	httpRoute := gatewayv1.SchemeGroupVersion.WithKind("HTTPRoute")
+	if _, err := mgr.GetRESTMapper().RESTMapping(httpRoute.GroupKind(), httpRoute.Version); err == nil {
+		sources = append(sources, discoverySource{
+			kind: "HTTPRoute", prefix: "route", newObject: func() client.Object { return &gatewayv1.HTTPRoute{} }, target: httpRouteTarget,
+		})
+	} else if meta.IsNoMatchError(err) {
+		mgr.GetLogger().Info("Gateway API HTTPRoute CRD not installed, skipping HTTPRoute discovery")
+	} else {
+		return err
+	}
+
+	for _, source := range sources {
+		err := ctrl.NewControllerManagedBy(mgr).
+			Named("discovery-"+strings.ToLower(source.kind)).
+			For(source.newObject(), builder.WithPredicates(hasDriverAnnotation)).
+			// Status updates of the monitors don't change what discovery would write
+			Owns(&monitorv1alpha1.EndpointMonitor{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+			Complete(&sourceReconciler{DiscoveryReconciler: r, source: source})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	Endpointmonitoringv1alpha1 "github.com/LiciousTech/endpoint-monitoring-operator/api/v1alpha1"
+)
+
+var _ = Describe("Discovery Controller", func() {
+	Context("When reconciling an annotated Service", func() {
+		const serviceName = "checkout"
+
+		ctx := context.Background()
+
+		serviceKey := types.NamespacedName{Name: serviceName, Namespace: "default"}
+		monitorKey := types.NamespacedName{Name: "svc-" + serviceName, Namespace: "default"}
+
+		newReconciler := func() *sourceReconciler {
+			return &sourceReconciler{
+				DiscoveryReconciler: &DiscoveryReconciler{
+					Client: k8sClient,
+					Scheme: k8sClient.Scheme(),
+					Notify: Endpointmonitoringv1alpha1.NotifyConfig{
+						Slack: &Endpointmonitoringv1alpha1.SlackConfig{Enabled: true, WebhookURL: "https://hooks.slack.com/services/test"},
+					},
+				},
+				source: discoverySource{
+					kind:      "Service",
+					prefix:    "svc",
+					newObject: func() client.Object { return &corev1.Service{} },
+					target:    serviceTarget,
+				},
+			}
+		}
+
+		BeforeEach(func() {
+			By("creating an annotated Service")
+			service := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      serviceName,
+					Namespace: "default",
+					Annotations: map[string]string{
+						AnnotationDriver:   "http",
+						AnnotationPath:     "/healthz",
+						AnnotationInterval: "30s",
+						AnnotationChannel:  "#checkout-alerts",
+					},
+				},
+				Spec: corev1.ServiceSpec{
+					Ports: []corev1.ServicePort{{Name: "http", Port: 8080}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, service)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			By("cleaning up the Service and its monitor")
+			Expect(k8sClient.Delete(ctx, &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: serviceName, Namespace: "default"},
+			})).To(Succeed())
+			monitor := &Endpointmonitoringv1alpha1.EndpointMonitor{}
+			if err := k8sClient.Get(ctx, monitorKey, monitor); err == nil {
+				Expect(k8sClient.Delete(ctx, monitor)).To(Succeed())
+			}
+		})
+
+		It("should create an owned monitor and remove it with the annotation", func() {
+			_, err := newReconciler().Reconcile(ctx, reconcile.Request{NamespacedName: serviceKey})
+			Expect(err).NotTo(HaveOccurred())
+
+			monitor := &Endpointmonitoringv1alpha1.EndpointMonitor{}
+			Expect(k8sClient.Get(ctx, monitorKey, monitor)).To(Succeed())
+			Expect(monitor.Spec.Driver).To(Equal("http"))
+			Expect(monitor.Spec.Endpoint).To(Equal("http://checkout.default.svc:8080/healthz"))
+			Expect(monitor.Spec.Interval.Duration).To(Equal(30 * time.Second))
+			Expect(monitor.Spec.Notify.Slack.Channel).To(Equal("#checkout-alerts"))
+			Expect(monitor.Labels).To(HaveKeyWithValue(LabelDiscovered, "true"))
+			Expect(monitor.OwnerReferences).To(HaveLen(1))
+			Expect(monitor.OwnerReferences[0].Name).To(Equal(serviceName))
+
+			By("removing the driver annotation")
+			service := &corev1.Service{}
+			Expect(k8sClient.Get(ctx, serviceKey, service)).To(Succeed())
+			delete(service.Annotations, AnnotationDriver)
+			Expect(k8sClient.Update(ctx, service)).To(Succeed())
+
+			_, err = newReconciler().Reconcile(ctx, reconcile.Request{NamespacedName: serviceKey})
+			Expect(err).NotTo(HaveOccurred())
+			err = k8sClient.Get(ctx, monitorKey, monitor)
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+
+	It("should keep long monitor names within the object name limit", func() {
+		Expect(discoveredMonitorName("svc", "checkout")).To(Equal("svc-checkout"))
+
+		long := strings.Repeat("a", 249) + "-" + "b"
+		name := discoveredMonitorName("route", long)
+		Expect(name).To(HaveLen(253))
+		Expect(name).To(HavePrefix("route-aaaa"))
+		Expect(name).NotTo(Equal(discoveredMonitorName("route", strings.Repeat("a", 249)+"-c")))
+		Expect(validation.IsDNS1123Subdomain(name)).To(BeEmpty())
+	})
+})
@@ -3,9 +3,11 @@ package controller
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -13,14 +15,43 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	monitorv1alpha1 "github.com/LiciousTech/endpoint-monitoring-operator/api/v1alpha1"
+	"github.com/LiciousTech/endpoint-monitoring-operator/internal/driver"
+	"github.com/LiciousTech/endpoint-monitoring-operator/internal/metrics"
+	"github.com/LiciousTech/endpoint-monitoring-operator/internal/store"
 	"github.com/LiciousTech/endpoint-monitoring-operator/pkg/factory"
 )
 
+// maxStatusMessageLength bounds the check message stored in the monitor status
+const maxStatusMessageLength = 1024
+
+// ConditionSuspended is true while spec.suspend pauses the monitor's checks
+const ConditionSuspended = "Suspended"
+
+// ConditionReady is false while a monitor's schedule or time zone is invalid, and true
+// while an EndpointSLO can be evaluated
+const ConditionReady = "Ready"
+
+// AnnotationRunNow requests an immediate check; any new value, typically a timestamp, triggers one
+const AnnotationRunNow = "endpointmonitor.licious.io/run-now"
+
 type EndpointMonitorReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+	// Store optionally persists every check result beyond the history kept in status
+	Store *store.Store
+	// AllowCrossNamespaceTargets lets kubernetes checks target workloads outside the monitor's namespace
+	AllowCrossNamespaceTargets bool
 }
 
+// +kubebuilder:rbac:groups=monitoring.licious.app,resources=endpointmonitors,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=monitoring.licious.app,resources=endpointmonitors/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=monitoring.licious.app,resources=endpointmonitors/finalizers,verbs=update
+// +kubebuilder:rbac:groups=monitoring.licious.app,resources=maintenancewindows,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get
+// +kubebuilder:rbac:groups="",resources=services;pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups=discovery.k8s.io,resources=endpointslices,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps,resources=deployments;statefulsets,verbs=get;list;watch
+
 func (r *EndpointMonitorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
@@ -33,59 +64,114 @@ func (r *EndpointMonitorReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	if err := r.Get(ctx, req.NamespacedName, &monitor); err != nil {
 		if errors.IsNotFound(err) {
 			logger.Info("EndpointMonitor resource not found. Ignoring since object must be deleted.")
+			metrics.Forget(req.Namespace, req.Name)
 			return ctrl.Result{}, nil
 		}
 		logger.Error(err, "Failed to get EndpointMonitor")
 		return ctrl.Result{}, err
 	}
 
+	// Suspended monitors return without a requeue, which drops any check still scheduled
+	if changed := setSuspended(&monitor); changed {
+		if err := r.Status().Update(ctx, &monitor); err != nil {
+			logger.Error(err, "Failed to update EndpointMonitor status")
+			return ctrl.Result{}, err
+		}
+	}
+	if monitor.Spec.Suspend {
+		logger.Info("Skipping reconcile; monitor is suspended", "name", monitor.Name)
+		return ctrl.Result{}, nil
+	}
+
+	// After a resume the next check is computed from LastCheckedTime as usual
 	now := time.Now()
-	checkInterval := time.Duration(monitor.Spec.CheckInterval) * time.Second
-	nextCheckTime := monitor.Status.LastCheckedTime.Time.Add(checkInterval)
+	nextCheck, err := nextCheckTime(&monitor)
+	if changed := setScheduleReady(&monitor, err); changed {
+		if err := r.Status().Update(ctx, &monitor); err != nil {
+			logger.Error(err, "Failed to update EndpointMonitor status")
+			return ctrl.Result{}, err
+		}
+	}
+	if err != nil {
+		// Retrying cannot fix the spec; its next update triggers a new reconcile
+		logger.Error(err, "Invalid schedule; waiting for the spec to change")
+		return ctrl.Result{}, nil
+	}
 
-	if !monitor.Status.LastCheckedTime.IsZero() && now.Before(nextCheckTime) {
-		requeueAfter := time.Until(nextCheckTime)
-		logger.Info("Skipping reconcile; checkInterval not yet elapsed",
+	// A new run-now trigger forces a check but leaves the regular schedule alone
+	trigger := monitor.Annotations[AnnotationRunNow]
+	triggered := trigger != "" && trigger != monitor.Status.LastRunNow
+	due := !now.Before(nextCheck)
+
+	if !due && !triggered {
+		requeueAfter := nextCheck.Sub(now)
+		logger.Info("Skipping reconcile; next check not yet due",
 			"name", monitor.Name,
 			"lastChecked", monitor.Status.LastCheckedTime.Time,
-			"nextCheckDue", nextCheckTime,
+			"nextCheckDue", nextCheck,
 			"requeueAfter", requeueAfter)
 		return ctrl.Result{RequeueAfter: requeueAfter}, nil
 	}
 
-	driver, err := factory.NewDriver(monitor.Spec.Driver, monitor.Spec.Endpoint, &monitor)
+	notifier, err := factory.NewNotifier(&monitor.Spec.Notify)
 	if err != nil {
-		logger.Error(err, "Failed to create driver")
+		logger.Error(err, "Failed to create notifier")
 		return ctrl.Result{}, err
 	}
 
-	notifier, err := factory.NewNotifier(&monitor.Spec.Notify)
+	results, err := r.checkEndpoints(ctx, &monitor)
 	if err != nil {
-		logger.Error(err, "Failed to create notifier")
+		logger.Error(err, "Failed to perform health check")
 		return ctrl.Result{}, err
 	}
 
-	result, err := driver.Check()
+	status, result, err := aggregateResults(&monitor.Spec, results)
 	if err != nil {
-		logger.Error(err, "Failed to perform health check")
+		logger.Error(err, "Failed to aggregate endpoint results")
 		return ctrl.Result{}, err
 	}
+	alertMessage := fmt.Sprintf("%s monitor for %s is %s\n%s",
+		results[0].driverType, describeTarget(&monitor.Spec, results), describeStatus(status), result.Message)
+	metrics.RecordCheck(monitor.Namespace, monitor.Name, monitor.Spec.Driver, status, result.ResponseTime)
 
-	var alertMessage, status string
-	if result.Success {
-		status = "success"
-		alertMessage = fmt.Sprintf("%s monitor for %s is healthy\n%s",
-			driver.GetType(), driver.GetEndpoint(), result.Message)
-	} else {
-		status = "failure"
-		alertMessage = fmt.Sprintf("%s monitor for %s is unhealthy\n%s",
-			driver.GetType(), driver.GetEndpoint(), result.Message)
+	if status != driver.StatusSuccess {
+		dependents, err := r.dependents(ctx, &monitor)
+		if err != nil {
+			logger.Error(err, "Failed to list dependent monitors")
+			return ctrl.Result{}, err
+		}
+		if len(dependents) > 0 {
+			alertMessage = fmt.Sprintf("%s\n%s", alertMessage, describeDependents(dependents))
+		}
 	}
 
-	if err := notifier.SendAlert(status, alertMessage); err != nil {
-		logger.Error(err, "Failed to send alert")
+	causes, err := r.rootCauses(ctx, &monitor)
+	if err != nil {
+		logger.Error(err, "Failed to get dependencies")
 		return ctrl.Result{}, err
 	}
+	muted, err := r.mutedReason(ctx, &monitor, now)
+	if err != nil {
+		logger.Error(err, "Failed to get maintenance windows")
+		return ctrl.Result{}, err
+	}
+	switch {
+	case len(causes) > 0:
+		logger.Info("Notification suppressed by failing dependency",
+			"name", monitor.Name,
+			"status", status,
+			"rootCause", strings.Join(causes, "; "))
+	case muted != "":
+		logger.Info("Notification muted",
+			"name", monitor.Name,
+			"status", status,
+			"reason", muted)
+	default:
+		if err := notifier.SendAlert(status, alertMessage); err != nil {
+			logger.Error(err, "Failed to send alert")
+			return ctrl.Result{}, err
+		}
+	}
 
 	updated := false
 	nowMetaTime := metav1.NewTime(now)
@@ -93,10 +179,45 @@ func (r *EndpointMonitorReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		monitor.Status.LastStatus = status
 		updated = true
 	}
-	if monitor.Status.LastCheckedTime != nowMetaTime {
+	if due && monitor.Status.LastCheckedTime != nowMetaTime {
 		monitor.Status.LastCheckedTime = nowMetaTime
 		updated = true
 	}
+	if triggered {
+		monitor.Status.LastRunNow = trigger
+		updated = true
+	}
+	if monitor.Status.LastResponseTime.Duration != result.ResponseTime {
+		monitor.Status.LastResponseTime = metav1.Duration{Duration: result.ResponseTime}
+		updated = true
+	}
+	if message := truncate(result.Message, maxStatusMessageLength); monitor.Status.LastMessage != message {
+		monitor.Status.LastMessage = message
+		updated = true
+	}
+
+	setSuppressed(&monitor, causes)
+	setMuted(&monitor, muted)
+
+	recordCheck(&monitor, now, status, result)
+	updated = true
+	if r.Store != nil {
+		// The persistent history is best effort and never fails the check
+		if err := r.Store.Record(req.String(), store.Result{
+			Time:         now,
+			Status:       status,
+			ResponseTime: result.ResponseTime,
+			Message:      truncate(result.Message, maxEndpointMessageLength),
+		}); err != nil {
+			logger.Error(err, "Failed to persist check result")
+		}
+	}
+
+	// Every check changes the history, so the per-endpoint entries are written with it
+	monitor.Status.Endpoints = nil
+	if len(monitor.Spec.Endpoints) > 0 {
+		monitor.Status.Endpoints = endpointStatuses(results)
+	}
 
 	if updated {
 		if err := r.Status().Update(ctx, &monitor); err != nil {
@@ -107,10 +228,100 @@ func (r *EndpointMonitorReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 
 	logger.Info("Reconciliation complete",
 		"name", monitor.Name,
+		"runNow", triggered,
 		"status", status,
 		"responseTime", result.ResponseTime.String())
 
-	return ctrl.Result{RequeueAfter: checkInterval}, nil
+	if due {
+		// The schedule was validated before the check, so this cannot fail
+		nextCheck, _ = nextCheckTime(&monitor)
+	}
+	return ctrl.Result{RequeueAfter: nextCheck.Sub(now)}, nil
+}
+
+// evaluateStatus maps a check result to success, degraded or failure. Successful
+// checks are downgraded when their response time exceeds the configured latency thresholds.
+func evaluateStatus(spec *monitorv1alpha1.EndpointMonitorSpec, result *driver.CheckResult) string {
+	if !result.Success {
+		return driver.StatusFailure
+	}
+
+	if spec.CriticalLatency != nil && spec.CriticalLatency.Duration > 0 && result.ResponseTime > spec.CriticalLatency.Duration {
+		result.Message = fmt.Sprintf("%s\nresponse time %v exceeds critical latency %v",
+			result.Message, result.ResponseTime, spec.CriticalLatency.Duration)
+		return driver.StatusFailure
+	}
+
+	if spec.WarningLatency != nil && spec.WarningLatency.Duration > 0 && result.ResponseTime > spec.WarningLatency.Duration {
+		result.Message = fmt.Sprintf("%s\nresponse time %v exceeds warning latency %v",
+			result.Message, result.ResponseTime, spec.WarningLatency.Duration)
+		return driver.StatusDegraded
+	}
+
+	if result.Degraded {
+		return driver.StatusDegraded
+	}
+	return driver.StatusSuccess
+}
+
+// setSuspended mirrors spec.suspend into the Suspended condition and reports whether it
+// changed. Monitors that were never suspended carry no Suspended condition.
+func setSuspended(monitor *monitorv1alpha1.EndpointMonitor) bool {
+	cond := metav1.Condition{
+		Type:               ConditionSuspended,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: monitor.Generation,
+		Reason:             "Suspended",
+		Message:            "Checks are paused by spec.suspend",
+	}
+	if !monitor.Spec.Suspend {
+		if meta.FindStatusCondition(monitor.Status.Conditions, ConditionSuspended) == nil {
+			return false
+		}
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = "Resumed"
+		cond.Message = "Checks resumed"
+	}
+	return meta.SetStatusCondition(&monitor.Status.Conditions, cond)
+}
+
+// setScheduleReady reports an invalid schedule or time zone in the Ready condition and
+// reports whether it changed. Monitors that were always schedulable carry no Ready condition.
+func setScheduleReady(monitor *monitorv1alpha1.EndpointMonitor, scheduleErr error) bool {
+	cond := metav1.Condition{
+		Type:               ConditionReady,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: monitor.Generation,
+		Reason:             "Scheduled",
+		Message:            "Checks are scheduled",
+	}
+	if scheduleErr != nil {
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = "InvalidSchedule"
+		cond.Message = truncate(scheduleErr.Error(), maxStatusMessageLength)
+	} else if meta.FindStatusCondition(monitor.Status.Conditions, ConditionReady) == nil {
+		return false
+	}
+	return meta.SetStatusCondition(&monitor.Status.Conditions, cond)
+}
+
+// describeStatus returns the wording used for a status in alert messages
+func describeStatus(status string) string {
+	switch status {
+	case driver.StatusSuccess:
+		return "healthy"
+	case driver.StatusDegraded:
+		return "degraded"
+	default:
+		return "unhealthy"
+	}
+}
+
+func truncate(s string, limit int) string {
+	if len(s) <= limit {
+		return s
+	}
+	return s[:limit-3] + "..."
 }
 
 func (r *EndpointMonitorReconciler) SetupWithManager(mgr ctrl.Manager) error {
@@ -0,0 +1,172 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	monitorv1alpha1 "github.com/LiciousTech/endpoint-monitoring-operator/api/v1alpha1"
+	"github.com/LiciousTech/endpoint-monitoring-operator/internal/driver"
+	"github.com/LiciousTech/endpoint-monitoring-operator/pkg/factory"
+)
+
+// Aggregation policies for monitors with several endpoints
+const (
+	AggregationAll    = "all"
+	AggregationAny    = "any"
+	AggregationQuorum = "quorum"
+)
+
+// maxEndpointMessageLength bounds the per-endpoint message stored in the monitor status
+const maxEndpointMessageLength = 256
+
+// endpointResult is the evaluated check of a single endpoint
+type endpointResult struct {
+	driverType string
+	endpoint   string
+	status     string
+	result     *driver.CheckResult
+}
+
+// checkEndpoints checks spec.endpoints in parallel, or spec.endpoint when no list is given.
+// Driver configuration errors and check errors are returned rather than counted as failures.
+func (r *EndpointMonitorReconciler) checkEndpoints(ctx context.Context, monitor *monitorv1alpha1.EndpointMonitor) ([]endpointResult, error) {
+	targets := monitor.Spec.Endpoints
+	if len(targets) == 0 {
+		targets = []string{monitor.Spec.Endpoint}
+	} else if _, err := requiredEndpoints(&monitor.Spec, len(targets)); err != nil {
+		return nil, err
+	}
+
+	drivers := make([]driver.Driver, len(targets))
+	f := &factory.DriverFactory{Client: r.Client, AllowCrossNamespaceTargets: r.AllowCrossNamespaceTargets}
+	for i, endpoint := range targets {
+		d, err := f.CreateDriver(ctx, monitor.Spec.Driver, endpoint, monitor)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create driver for %s: %w", endpoint, err)
+		}
+		drivers[i] = d
+	}
+
+	results := make([]endpointResult, len(drivers))
+	errs := make([]error, len(drivers))
+	var wg sync.WaitGroup
+	for i, d := range drivers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := d.Check()
+			if err != nil {
+				errs[i] = fmt.Errorf("failed to check %s: %w", d.GetEndpoint(), err)
+				return
+			}
+			results[i] = endpointResult{
+				driverType: d.GetType(),
+				endpoint:   d.GetEndpoint(),
+				status:     evaluateStatus(&monitor.Spec, result),
+				result:     result,
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+// aggregateResults folds per-endpoint results into one status and check result. The
+// monitor fails when fewer endpoints than required are up and is degraded while any
+// endpoint is not fully healthy. The response time is that of the slowest endpoint.
+func aggregateResults(spec *monitorv1alpha1.EndpointMonitorSpec, results []endpointResult) (string, *driver.CheckResult, error) {
+	if len(spec.Endpoints) == 0 {
+		return results[0].status, results[0].result, nil
+	}
+
+	required, err := requiredEndpoints(spec, len(results))
+	if err != nil {
+		return "", nil, err
+	}
+
+	var up, healthy int
+	aggregate := &driver.CheckResult{}
+	lines := make([]string, 0, len(results))
+	for _, res := range results {
+		if res.status != driver.StatusFailure {
+			up++
+		}
+		if res.status == driver.StatusSuccess {
+			healthy++
+		}
+		aggregate.ResponseTime = max(aggregate.ResponseTime, res.result.ResponseTime)
+		lines = append(lines, fmt.Sprintf("- %s: %s", res.endpoint, res.result.Message))
+	}
+
+	status := driver.StatusSuccess
+	switch {
+	case up < required:
+		status = driver.StatusFailure
+	case healthy < len(results):
+		status = driver.StatusDegraded
+	}
+	aggregate.Success = status != driver.StatusFailure
+	aggregate.Degraded = status == driver.StatusDegraded
+	aggregate.Message = fmt.Sprintf("%d/%d endpoints up, %d required (%s)\n%s",
+		up, len(results), required, aggregationPolicy(spec), strings.Join(lines, "\n"))
+	return status, aggregate, nil
+}
+
+// requiredEndpoints returns how many endpoints must be up for the monitor to pass
+func requiredEndpoints(spec *monitorv1alpha1.EndpointMonitorSpec, total int) (int, error) {
+	switch aggregationPolicy(spec) {
+	case AggregationAll:
+		return total, nil
+	case AggregationAny:
+		return 1, nil
+	case AggregationQuorum:
+		if spec.Quorum == nil {
+			return total/2 + 1, nil
+		}
+		if *spec.Quorum < 1 {
+			return 0, fmt.Errorf("quorum must be at least 1, got %d", *spec.Quorum)
+		}
+		return min(int(*spec.Quorum), total), nil
+	default:
+		return 0, fmt.Errorf("unsupported aggregation %q, expected all, any or quorum", spec.Aggregation)
+	}
+}
+
+func aggregationPolicy(spec *monitorv1alpha1.EndpointMonitorSpec) string {
+	if spec.Aggregation == "" {
+		return AggregationAll
+	}
+	return spec.Aggregation
+}
+
+// endpointStatuses converts results into the per-endpoint status entries
+func endpointStatuses(results []endpointResult) []monitorv1alpha1.EndpointStatus {
+	statuses := make([]monitorv1alpha1.EndpointStatus, len(results))
+	for i, res := range results {
+		statuses[i] = monitorv1alpha1.EndpointStatus{
+			Endpoint:     res.endpoint,
+			Status:       res.status,
+			ResponseTime: metav1.Duration{Duration: res.result.ResponseTime},
+			Message:      truncate(res.result.Message, maxEndpointMessageLength),
+		}
+	}
+	return statuses
+}
+
+// describeTarget names what an alert is about: the single endpoint or the endpoint count
+func describeTarget(spec *monitorv1alpha1.EndpointMonitorSpec, results []endpointResult) string {
+	if len(spec.Endpoints) == 0 {
+		return results[0].endpoint
+	}
+	return fmt.Sprintf("%d endpoints", len(results))
+}
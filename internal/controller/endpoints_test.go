@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	Endpointmonitoringv1alpha1 "github.com/LiciousTech/endpoint-monitoring-operator/api/v1alpha1"
+	"github.com/LiciousTech/endpoint-monitoring-operator/internal/driver"
+)
+
+var _ = Describe("Endpoint aggregation", func() {
+	results := func(statuses ...string) []endpointResult {
+		out := make([]endpointResult, len(statuses))
+		for i, status := range statuses {
+			out[i] = endpointResult{
+				driverType: "trino",
+				endpoint:   "http://coordinator-" + string(rune('a'+i)) + ":8080",
+				status:     status,
+				result:     &driver.CheckResult{ResponseTime: time.Duration(i+1) * time.Second, Message: status},
+			}
+		}
+		return out
+	}
+	spec := func(aggregation string, quorum *int32) *Endpointmonitoringv1alpha1.EndpointMonitorSpec {
+		return &Endpointmonitoringv1alpha1.EndpointMonitorSpec{
+			Endpoints:   []string{"a", "b", "c"},
+			Aggregation: aggregation,
+			Quorum:      quorum,
+		}
+	}
+	two := int32(2)
+
+	DescribeTable("should apply the aggregation policy",
+		func(s *Endpointmonitoringv1alpha1.EndpointMonitorSpec, statuses []string, expected string) {
+			status, result, err := aggregateResults(s, results(statuses...))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status).To(Equal(expected))
+			Expect(result.ResponseTime).To(Equal(3 * time.Second))
+		},
+		Entry("all healthy", spec("", nil),
+			[]string{driver.StatusSuccess, driver.StatusSuccess, driver.StatusSuccess}, driver.StatusSuccess),
+		Entry("all with one down", spec(AggregationAll, nil),
+			[]string{driver.StatusSuccess, driver.StatusFailure, driver.StatusSuccess}, driver.StatusFailure),
+		Entry("all with one degraded", spec(AggregationAll, nil),
+			[]string{driver.StatusSuccess, driver.StatusDegraded, driver.StatusSuccess}, driver.StatusDegraded),
+		Entry("any with one up", spec(AggregationAny, nil),
+			[]string{driver.StatusFailure, driver.StatusFailure, driver.StatusSuccess}, driver.StatusDegraded),
+		Entry("any with none up", spec(AggregationAny, nil),
+			[]string{driver.StatusFailure, driver.StatusFailure, driver.StatusFailure}, driver.StatusFailure),
+		Entry("majority quorum met", spec(AggregationQuorum, nil),
+			[]string{driver.StatusSuccess, driver.StatusFailure, driver.StatusSuccess}, driver.StatusDegraded),
+		Entry("explicit quorum missed", spec(AggregationQuorum, &two),
+			[]string{driver.StatusSuccess, driver.StatusFailure, driver.StatusFailure}, driver.StatusFailure),
+	)
+
+	It("should summarize every endpoint in one message", func() {
+		_, result, err := aggregateResults(spec(AggregationQuorum, nil),
+			results(driver.StatusSuccess, driver.StatusFailure, driver.StatusSuccess))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Message).To(HavePrefix("2/3 endpoints up, 2 required (quorum)\n"))
+		Expect(result.Message).To(ContainSubstring("- http://coordinator-b:8080: failure"))
+	})
+
+	It("should reject unknown policies", func() {
+		_, _, err := aggregateResults(spec("most", nil), results(driver.StatusSuccess))
+		Expect(err).To(MatchError(ContainSubstring(`unsupported aggregation "most"`)))
+	})
+})
@@ -0,0 +1,397 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	monitorv1alpha1 "github.com/LiciousTech/endpoint-monitoring-operator/api/v1alpha1"
+	"github.com/LiciousTech/endpoint-monitoring-operator/internal/driver"
+	"github.com/LiciousTech/endpoint-monitoring-operator/internal/metrics"
+	"github.com/LiciousTech/endpoint-monitoring-operator/internal/store"
+	"github.com/LiciousTech/endpoint-monitoring-operator/pkg/factory"
+)
+
+const (
+	// ConditionAlerting is true while any burn-rate alert of an EndpointSLO fires
+	ConditionAlerting = "Alerting"
+
+	defaultSLOWindow   = 30 * 24 * time.Hour
+	defaultSLOInterval = time.Minute
+)
+
+// defaultBurnRateAlerts are the multi-window, multi-burn-rate alerts recommended by the
+// Google SRE workbook for a 30 day window: 2% of the budget in an hour, 5% in six hours,
+// 10% in a day and 10% in three days.
+var defaultBurnRateAlerts = []monitorv1alpha1.BurnRateAlert{
+	{Name: "page-fast", LongWindow: metav1.Duration{Duration: time.Hour}, ShortWindow: metav1.Duration{Duration: 5 * time.Minute}, BurnRate: "14.4"},
+	{Name: "page-slow", LongWindow: metav1.Duration{Duration: 6 * time.Hour}, ShortWindow: metav1.Duration{Duration: 30 * time.Minute}, BurnRate: "6"},
+	{Name: "ticket-fast", LongWindow: metav1.Duration{Duration: 24 * time.Hour}, ShortWindow: metav1.Duration{Duration: 2 * time.Hour}, BurnRate: "3"},
+	{Name: "ticket-slow", LongWindow: metav1.Duration{Duration: 72 * time.Hour}, ShortWindow: metav1.Duration{Duration: 6 * time.Hour}, BurnRate: "1"},
+}
+
+// EndpointSLOReconciler evaluates EndpointSLOs against the check history of their monitors
+type EndpointSLOReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	// Store provides the check history when persistence is enabled; otherwise the
+	// history and hourly uptime buckets in the monitors' status are used, which limits
+	// every window to 7 days
+	Store *store.Store
+}
+
+// checkCounts is the number of checks and failed checks in a time range
+type checkCounts struct {
+	checks, failures int64
+}
+
+func (c checkCounts) add(other checkCounts) checkCounts {
+	return checkCounts{checks: c.checks + other.checks, failures: c.failures + other.failures}
+}
+
+// errorRate is the fraction of failed checks, 0 without checks
+func (c checkCounts) errorRate() float64 {
+	if c.checks == 0 {
+		return 0
+	}
+	return float64(c.failures) / float64(c.checks)
+}
+
+// +kubebuilder:rbac:groups=monitoring.licious.app,resources=endpointslos,verbs=get;list;watch
+// +kubebuilder:rbac:groups=monitoring.licious.app,resources=endpointslos/status,verbs=get;update;patch
+
+func (r *EndpointSLOReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	var slo monitorv1alpha1.EndpointSLO
+	if err := r.Get(ctx, req.NamespacedName, &slo); err != nil {
+		if errors.IsNotFound(err) {
+			metrics.ForgetSLO(req.Namespace, req.Name)
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Failed to get EndpointSLO")
+		return ctrl.Result{}, err
+	}
+
+	interval := defaultSLOInterval
+	if slo.Spec.Interval != nil && slo.Spec.Interval.Duration > 0 {
+		interval = slo.Spec.Interval.Duration
+	}
+
+	objective, alerts, err := parseSLOSpec(&slo.Spec)
+	if err != nil {
+		// Wait for the spec to be fixed; the update triggers the next reconcile
+		logger.Info("Invalid EndpointSLO", "error", err.Error())
+		return ctrl.Result{}, r.setNotReady(ctx, &slo, "InvalidSpec", err.Error())
+	}
+
+	monitors, missing, err := r.sloMonitors(ctx, &slo)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if len(missing) > 0 {
+		message := fmt.Sprintf("monitors not found: %s", strings.Join(missing, ", "))
+		return ctrl.Result{RequeueAfter: interval}, r.setNotReady(ctx, &slo, "MonitorNotFound", message)
+	}
+
+	now := time.Now()
+	window := defaultSLOWindow
+	if slo.Spec.Window != nil && slo.Spec.Window.Duration > 0 {
+		window = slo.Spec.Window.Duration
+	}
+	budget := 1 - objective
+	// Without the store only the hourly buckets in the monitors' status reach back, so the
+	// evaluation is limited to them and the Ready condition says so
+	evaluated := window
+	if r.Store == nil {
+		evaluated = min(window, uptimeRetention)
+	}
+
+	counts := func(since time.Duration) (checkCounts, error) {
+		var total checkCounts
+		for _, monitor := range monitors {
+			c, err := r.countChecks(monitor, now.Add(-min(since, evaluated)), now)
+			if err != nil {
+				return checkCounts{}, err
+			}
+			total = total.add(c)
+		}
+		return total, nil
+	}
+
+	overall, err := counts(window)
+	if err != nil {
+		logger.Error(err, "Failed to read check history")
+		return ctrl.Result{}, err
+	}
+	availability := 1 - overall.errorRate()
+	budgetRemaining := 1 - overall.errorRate()/budget
+
+	burns := make([]metrics.SLOBurn, 0, len(alerts))
+	var firing []string
+	for _, alert := range alerts {
+		long, err := counts(alert.LongWindow.Duration)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		short, err := counts(alert.ShortWindow.Duration)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		burn := metrics.SLOBurn{
+			Alert:     alert.Name,
+			LongBurn:  long.errorRate() / budget,
+			ShortBurn: short.errorRate() / budget,
+		}
+		burn.Firing = burn.LongBurn >= alert.factor && burn.ShortBurn >= alert.factor
+		if burn.Firing {
+			firing = append(firing, alert.Name)
+		}
+		burns = append(burns, burn)
+	}
+	metrics.RecordSLO(slo.Namespace, slo.Name, availability, budgetRemaining, burns)
+
+	summary := fmt.Sprintf("availability %s%% over %s (objective %s%%), %s%% of the error budget left",
+		formatPercent(availability), evaluated, slo.Spec.Objective, formatPercent(budgetRemaining))
+
+	wasFiring := slo.Status.FiringAlerts
+	slo.Status.LastEvaluatedTime = metav1.NewTime(now)
+	slo.Status.Availability = formatPercent(availability)
+	slo.Status.ErrorBudgetRemaining = formatPercent(budgetRemaining)
+	slo.Status.Checks = overall.checks
+	slo.Status.Failures = overall.failures
+	slo.Status.FiringAlerts = firing
+	slo.Status.BurnRates = make([]monitorv1alpha1.BurnRateStatus, 0, len(burns))
+	for _, burn := range burns {
+		slo.Status.BurnRates = append(slo.Status.BurnRates, monitorv1alpha1.BurnRateStatus{
+			Alert:     burn.Alert,
+			LongBurn:  strconv.FormatFloat(burn.LongBurn, 'f', 2, 64),
+			ShortBurn: strconv.FormatFloat(burn.ShortBurn, 'f', 2, 64),
+			Firing:    burn.Firing,
+		})
+	}
+	ready := metav1.Condition{
+		Type:               ConditionReady,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: slo.Generation,
+		Reason:             "Evaluated",
+		Message:            fmt.Sprintf("%d checks of %d monitors in the window", overall.checks, len(monitors)),
+	}
+	if evaluated < window {
+		ready.Reason = "WindowLimited"
+		ready.Message = fmt.Sprintf("%d checks of %d monitors in the last %s only; the %s window needs the "+
+			"persistent check history (--history-path)", overall.checks, len(monitors), evaluated, window)
+	}
+	meta.SetStatusCondition(&slo.Status.Conditions, ready)
+	alerting := metav1.Condition{
+		Type:               ConditionAlerting,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: slo.Generation,
+		Reason:             "WithinBudget",
+		Message:            summary,
+	}
+	if len(firing) > 0 {
+		alerting.Status = metav1.ConditionTrue
+		alerting.Reason = "BurnRateExceeded"
+		alerting.Message = truncate(fmt.Sprintf("firing: %s; %s", strings.Join(firing, ", "), summary), maxStatusMessageLength)
+	}
+	meta.SetStatusCondition(&slo.Status.Conditions, alerting)
+
+	if err := r.Status().Update(ctx, &slo); err != nil {
+		logger.Error(err, "Failed to update EndpointSLO status")
+		return ctrl.Result{}, err
+	}
+	// Notify only once the new firing alerts are persisted, so a conflicting update
+	// re-evaluates against the old ones instead of sending the same transition twice
+	r.notifyTransitions(ctx, &slo, wasFiring, alerts, burns, summary)
+	return ctrl.Result{RequeueAfter: interval}, nil
+}
+
+// parsedBurnRateAlert is a BurnRateAlert with its parsed factor
+type parsedBurnRateAlert struct {
+	monitorv1alpha1.BurnRateAlert
+	factor float64
+}
+
+// parseSLOSpec returns the objective as a fraction and the alerts with their factors
+func parseSLOSpec(spec *monitorv1alpha1.EndpointSLOSpec) (float64, []parsedBurnRateAlert, error) {
+	if len(spec.Monitors) == 0 {
+		return 0, nil, fmt.Errorf("at least one monitor is required")
+	}
+	percent, err := strconv.ParseFloat(spec.Objective, 64)
+	if err != nil || percent <= 0 || percent >= 100 {
+		return 0, nil, fmt.Errorf("objective %q must be a percentage between 0 and 100, e.g. \"99.9\"", spec.Objective)
+	}
+
+	alerts := spec.Alerts
+	if len(alerts) == 0 {
+		alerts = defaultBurnRateAlerts
+	}
+	parsed := make([]parsedBurnRateAlert, 0, len(alerts))
+	for _, alert := range alerts {
+		factor, err := strconv.ParseFloat(alert.BurnRate, 64)
+		if err != nil || factor <= 0 {
+			return 0, nil, fmt.Errorf("alert %q: burnRate %q must be a positive number", alert.Name, alert.BurnRate)
+		}
+		if alert.ShortWindow.Duration <= 0 || alert.LongWindow.Duration < alert.ShortWindow.Duration {
+			return 0, nil, fmt.Errorf("alert %q: shortWindow must be positive and no longer than longWindow", alert.Name)
+		}
+		parsed = append(parsed, parsedBurnRateAlert{BurnRateAlert: alert, factor: factor})
+	}
+	return percent / 100, parsed, nil
+}
+
+// sloMonitors fetches the monitors of slo and names those that do not exist
+func (r *EndpointSLOReconciler) sloMonitors(ctx context.Context, slo *monitorv1alpha1.EndpointSLO) ([]*monitorv1alpha1.EndpointMonitor, []string, error) {
+	var monitors []*monitorv1alpha1.EndpointMonitor
+	var missing []string
+	for _, ref := range slo.Spec.Monitors {
+		key := types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}
+		if key.Namespace == "" {
+			key.Namespace = slo.Namespace
+		}
+		monitor := &monitorv1alpha1.EndpointMonitor{}
+		if err := r.Get(ctx, key, monitor); err != nil {
+			if errors.IsNotFound(err) {
+				missing = append(missing, key.String())
+				continue
+			}
+			return nil, nil, err
+		}
+		monitors = append(monitors, monitor)
+	}
+	return monitors, missing, nil
+}
+
+// countChecks counts the checks of monitor in (from, now], from the store when it is enabled
+func (r *EndpointSLOReconciler) countChecks(monitor *monitorv1alpha1.EndpointMonitor, from, now time.Time) (checkCounts, error) {
+	if r.Store != nil {
+		summary, err := r.Store.Summarize(client.ObjectKeyFromObject(monitor).String(), from, now.Add(time.Nanosecond), now)
+		if err != nil {
+			return checkCounts{}, err
+		}
+		return checkCounts{checks: summary.Checks, failures: summary.Failures}, nil
+	}
+	return statusChecks(&monitor.Status, from), nil
+}
+
+// statusChecks counts the checks after from recorded in a monitor's status. The history
+// is exact but short, so ranges it does not cover fall back to whole hourly buckets.
+func statusChecks(status *monitorv1alpha1.EndpointMonitorStatus, from time.Time) checkCounts {
+	var counts checkCounts
+	if len(status.History) > 0 && !status.History[0].Time.After(from) {
+		for _, record := range status.History {
+			if record.Time.After(from) {
+				counts.checks++
+				if record.Status == driver.StatusFailure {
+					counts.failures++
+				}
+			}
+		}
+		return counts
+	}
+	if status.Uptime == nil {
+		return counts
+	}
+	for _, bucket := range status.Uptime.HourlyBuckets {
+		if bucket.Hour.Add(time.Hour).After(from) {
+			counts.checks += int64(bucket.Checks)
+			counts.failures += int64(bucket.Checks - bucket.Up)
+		}
+	}
+	return counts
+}
+
+// sloNotification is a notifier status and message
+type sloNotification struct {
+	status, text string
+}
+
+// notifyTransitions sends a failure notification for every alert that starts firing and a
+// success notification for every alert that stops, compared with the alerts in wasFiring;
+// Notify.*.alertOn filters them as for monitors
+func (r *EndpointSLOReconciler) notifyTransitions(ctx context.Context, slo *monitorv1alpha1.EndpointSLO,
+	wasFiring []string, alerts []parsedBurnRateAlert, burns []metrics.SLOBurn, summary string) {
+	logger := log.FromContext(ctx)
+	if !notifyEnabled(&slo.Spec.Notify) {
+		return
+	}
+
+	var messages []sloNotification
+	for i, burn := range burns {
+		if burn.Firing == slices.Contains(wasFiring, burn.Alert) {
+			continue
+		}
+		alert := alerts[i]
+		state, status := "resolved", driver.StatusSuccess
+		if burn.Firing {
+			state, status = "firing", driver.StatusFailure
+		}
+		messages = append(messages, sloNotification{status, fmt.Sprintf(
+			"SLO %s/%s burn-rate alert %s is %s\nburn rate %.1fx over %s and %.1fx over %s (threshold %sx)\n%s",
+			slo.Namespace, slo.Name, alert.Name, state,
+			burn.LongBurn, alert.LongWindow.Duration, burn.ShortBurn, alert.ShortWindow.Duration, alert.BurnRate,
+			summary)})
+	}
+	if len(messages) == 0 {
+		return
+	}
+
+	notifier, err := factory.NewNotifier(&slo.Spec.Notify)
+	if err != nil {
+		logger.Error(err, "Failed to create notifier")
+		return
+	}
+	for _, message := range messages {
+		// A failed notification is not retried, as the alert state is recorded regardless
+		if err := notifier.SendAlert(message.status, message.text); err != nil {
+			logger.Error(err, "Failed to send burn-rate alert")
+		}
+	}
+}
+
+// notifyEnabled reports whether any notifier is enabled
+func notifyEnabled(config *monitorv1alpha1.NotifyConfig) bool {
+	return (config.Slack != nil && config.Slack.Enabled) || (config.Email != nil && config.Email.Enabled)
+}
+
+// setNotReady records why slo cannot be evaluated
+func (r *EndpointSLOReconciler) setNotReady(ctx context.Context, slo *monitorv1alpha1.EndpointSLO, reason, message string) error {
+	changed := meta.SetStatusCondition(&slo.Status.Conditions, metav1.Condition{
+		Type:               ConditionReady,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: slo.Generation,
+		Reason:             reason,
+		Message:            truncate(message, maxStatusMessageLength),
+	})
+	if !changed {
+		return nil
+	}
+	return r.Status().Update(ctx, slo)
+}
+
+// formatPercent formats a fraction as a percentage with three decimals, enough for "99.999"
+func formatPercent(fraction float64) string {
+	return strconv.FormatFloat(100*fraction, 'f', 3, 64)
+}
+
+func (r *EndpointSLOReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		// Evaluations requeue themselves, so status updates must not trigger another one
+		For(&monitorv1alpha1.EndpointSLO{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
@@ -0,0 +1,199 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	Endpointmonitoringv1alpha1 "github.com/LiciousTech/endpoint-monitoring-operator/api/v1alpha1"
+	"github.com/LiciousTech/endpoint-monitoring-operator/internal/driver"
+)
+
+var _ = Describe("EndpointSLO Controller", func() {
+	ctx := context.Background()
+	monitorKey := types.NamespacedName{Name: "payments-api", Namespace: "default"}
+	sloKey := types.NamespacedName{Name: "payments-availability", Namespace: "default"}
+
+	var (
+		mu       sync.Mutex
+		messages []string
+	)
+
+	// setHistory replaces the monitor's status with checks over the last 20 minutes,
+	// the most recent failing ones of them failures
+	setHistory := func(failing int) {
+		monitor := &Endpointmonitoringv1alpha1.EndpointMonitor{}
+		Expect(k8sClient.Get(ctx, monitorKey, monitor)).To(Succeed())
+		monitor.Status = Endpointmonitoringv1alpha1.EndpointMonitorStatus{}
+		now := time.Now().Truncate(time.Second)
+		for i := range 20 {
+			status := driver.StatusSuccess
+			if i >= 20-failing {
+				status = driver.StatusFailure
+			}
+			recordCheck(monitor, now.Add(time.Duration(i-19)*time.Minute), status, &driver.CheckResult{ResponseTime: time.Millisecond})
+		}
+		Expect(k8sClient.Status().Update(ctx, monitor)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		mu.Lock()
+		messages = nil
+		mu.Unlock()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var payload map[string]string
+			Expect(json.NewDecoder(r.Body).Decode(&payload)).To(Succeed())
+			mu.Lock()
+			messages = append(messages, payload["text"])
+			mu.Unlock()
+			w.WriteHeader(http.StatusOK)
+		}))
+		DeferCleanup(server.Close)
+
+		Expect(k8sClient.Create(ctx, &Endpointmonitoringv1alpha1.EndpointMonitor{
+			ObjectMeta: metav1.ObjectMeta{Name: monitorKey.Name, Namespace: monitorKey.Namespace},
+			Spec: Endpointmonitoringv1alpha1.EndpointMonitorSpec{
+				Driver:   "http",
+				Endpoint: "http://payments.default.svc/healthz",
+				Interval: &metav1.Duration{Duration: time.Minute},
+			},
+		})).To(Succeed())
+		Expect(k8sClient.Create(ctx, &Endpointmonitoringv1alpha1.EndpointSLO{
+			ObjectMeta: metav1.ObjectMeta{Name: sloKey.Name, Namespace: sloKey.Namespace},
+			Spec: Endpointmonitoringv1alpha1.EndpointSLOSpec{
+				Monitors:  []Endpointmonitoringv1alpha1.MonitorReference{{Name: monitorKey.Name}},
+				Objective: "99",
+				Alerts: []Endpointmonitoringv1alpha1.BurnRateAlert{{
+					Name:        "page",
+					LongWindow:  metav1.Duration{Duration: time.Hour},
+					ShortWindow: metav1.Duration{Duration: 5 * time.Minute},
+					BurnRate:    "14.4",
+				}},
+				Notify: Endpointmonitoringv1alpha1.NotifyConfig{
+					Slack: &Endpointmonitoringv1alpha1.SlackConfig{
+						Enabled:    true,
+						WebhookURL: server.URL,
+						AlertOn:    []string{driver.StatusFailure, driver.StatusSuccess},
+					},
+				},
+			},
+		})).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.Delete(ctx, &Endpointmonitoringv1alpha1.EndpointSLO{
+			ObjectMeta: metav1.ObjectMeta{Name: sloKey.Name, Namespace: sloKey.Namespace},
+		})).To(Succeed())
+		Expect(k8sClient.Delete(ctx, &Endpointmonitoringv1alpha1.EndpointMonitor{
+			ObjectMeta: metav1.ObjectMeta{Name: monitorKey.Name, Namespace: monitorKey.Namespace},
+		})).To(Succeed())
+	})
+
+	It("should fire and resolve burn-rate alerts from the monitor's history", func() {
+		reconciler := &EndpointSLOReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+
+		By("evaluating a monitor whose last ten checks failed")
+		setHistory(10)
+
+		By("losing the status update to a conflict")
+		watchClient, err := client.NewWithWatch(cfg, client.Options{Scheme: k8sClient.Scheme()})
+		Expect(err).NotTo(HaveOccurred())
+		conflicting := &EndpointSLOReconciler{Scheme: k8sClient.Scheme(), Client: interceptor.NewClient(watchClient, interceptor.Funcs{
+			SubResourceUpdate: func(context.Context, client.Client, string, client.Object, ...client.SubResourceUpdateOption) error {
+				return apierrors.NewConflict(schema.GroupResource{Resource: "endpointslos"}, sloKey.Name, nil)
+			},
+		})}
+		_, err = conflicting.Reconcile(ctx, reconcile.Request{NamespacedName: sloKey})
+		Expect(apierrors.IsConflict(err)).To(BeTrue())
+		mu.Lock()
+		// The alert is not persisted as firing yet, so it must not be announced either
+		Expect(messages).To(BeEmpty())
+		mu.Unlock()
+
+		result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: sloKey})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(defaultSLOInterval))
+
+		slo := &Endpointmonitoringv1alpha1.EndpointSLO{}
+		Expect(k8sClient.Get(ctx, sloKey, slo)).To(Succeed())
+		Expect(slo.Status.Checks).To(BeEquivalentTo(20))
+		Expect(slo.Status.Failures).To(BeEquivalentTo(10))
+		Expect(slo.Status.Availability).To(Equal("50.000"))
+		Expect(slo.Status.ErrorBudgetRemaining).To(Equal("-4900.000"))
+		Expect(slo.Status.FiringAlerts).To(Equal([]string{"page"}))
+		Expect(slo.Status.BurnRates).To(HaveLen(1))
+		Expect(slo.Status.BurnRates[0].ShortBurn).To(Equal("100.00"))
+		Expect(meta.IsStatusConditionTrue(slo.Status.Conditions, ConditionAlerting)).To(BeTrue())
+		// Without the store the default 720h window is limited to the week of hourly buckets
+		ready := meta.FindStatusCondition(slo.Status.Conditions, ConditionReady)
+		Expect(ready).NotTo(BeNil())
+		Expect(ready.Reason).To(Equal("WindowLimited"))
+		Expect(ready.Message).To(ContainSubstring("last 168h0m0s only"))
+
+		By("evaluating again while the alert keeps firing")
+		_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: sloKey})
+		Expect(err).NotTo(HaveOccurred())
+		mu.Lock()
+		Expect(messages).To(HaveLen(1))
+		Expect(messages[0]).To(ContainSubstring("burn-rate alert page is firing"))
+		Expect(messages[0]).To(ContainSubstring("availability 50.000% over 168h0m0s"))
+		mu.Unlock()
+
+		By("evaluating after the monitor recovered")
+		setHistory(0)
+		_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: sloKey})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(k8sClient.Get(ctx, sloKey, slo)).To(Succeed())
+		Expect(slo.Status.FiringAlerts).To(BeEmpty())
+		Expect(slo.Status.ErrorBudgetRemaining).To(Equal("100.000"))
+		Expect(meta.IsStatusConditionTrue(slo.Status.Conditions, ConditionAlerting)).To(BeFalse())
+		mu.Lock()
+		Expect(messages).To(HaveLen(2))
+		Expect(messages[1]).To(ContainSubstring("burn-rate alert page is resolved"))
+		mu.Unlock()
+	})
+
+	It("should report invalid objectives and missing monitors", func() {
+		reconciler := &EndpointSLOReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+
+		slo := &Endpointmonitoringv1alpha1.EndpointSLO{}
+		Expect(k8sClient.Get(ctx, sloKey, slo)).To(Succeed())
+		slo.Spec.Objective = "100"
+		Expect(k8sClient.Update(ctx, slo)).To(Succeed())
+
+		result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: sloKey})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(reconcile.Result{}))
+		Expect(k8sClient.Get(ctx, sloKey, slo)).To(Succeed())
+		cond := meta.FindStatusCondition(slo.Status.Conditions, ConditionReady)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Reason).To(Equal("InvalidSpec"))
+
+		By("referencing a monitor that does not exist")
+		slo.Spec.Objective = "99.9"
+		slo.Spec.Monitors = append(slo.Spec.Monitors, Endpointmonitoringv1alpha1.MonitorReference{Name: "missing"})
+		Expect(k8sClient.Update(ctx, slo)).To(Succeed())
+
+		_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: sloKey})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(k8sClient.Get(ctx, sloKey, slo)).To(Succeed())
+		cond = meta.FindStatusCondition(slo.Status.Conditions, ConditionReady)
+		Expect(cond.Reason).To(Equal("MonitorNotFound"))
+		Expect(cond.Message).To(ContainSubstring("default/missing"))
+	})
+})
@@ -0,0 +1,144 @@
+package controller
+
+import (
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	monitorv1alpha1 "github.com/LiciousTech/endpoint-monitoring-operator/api/v1alpha1"
+	"github.com/LiciousTech/endpoint-monitoring-operator/internal/driver"
+)
+
+const (
+	defaultHistoryLimit = 30
+	maxHistoryLimit     = 100
+	// maxHistoryMessageLength keeps a full history at roughly 20KB of status
+	maxHistoryMessageLength = 128
+	// uptimeRetention is the longest uptime window, covered by one bucket per hour
+	uptimeRetention = 7 * 24 * time.Hour
+)
+
+// historyLimit returns spec.historyLimit clamped to [1, maxHistoryLimit]
+func historyLimit(spec *monitorv1alpha1.EndpointMonitorSpec) int {
+	if spec.HistoryLimit == nil {
+		return defaultHistoryLimit
+	}
+	return min(max(int(*spec.HistoryLimit), 1), maxHistoryLimit)
+}
+
+// recordCheck appends a check to the bounded history, counts it in its hourly
+// uptime bucket and recomputes the uptime summary
+func recordCheck(monitor *monitorv1alpha1.EndpointMonitor, now time.Time, status string, result *driver.CheckResult) {
+	message, _, _ := strings.Cut(result.Message, "\n")
+	history := append(monitor.Status.History, monitorv1alpha1.CheckRecord{
+		Time:         metav1.NewTime(now),
+		Status:       status,
+		ResponseTime: metav1.Duration{Duration: result.ResponseTime},
+		Message:      truncate(message, maxHistoryMessageLength),
+	})
+	if excess := len(history) - historyLimit(&monitor.Spec); excess > 0 {
+		history = slices.Clone(history[excess:])
+	}
+	monitor.Status.History = history
+
+	uptime := monitor.Status.Uptime
+	if uptime == nil {
+		uptime = &monitorv1alpha1.UptimeStatus{}
+	}
+	uptime.HourlyBuckets = countCheck(uptime.HourlyBuckets, now, status != driver.StatusFailure)
+
+	hour := uptimeOfLastHour(uptime.HourlyBuckets, now)
+	day := uptimeOfBuckets(uptime.HourlyBuckets, now.Add(-24*time.Hour))
+	week := uptimeOfBuckets(uptime.HourlyBuckets, now.Add(-uptimeRetention))
+	uptime.LastHour, uptime.LastDay, uptime.LastWeek = hour, day, week
+	uptime.AverageLatency, uptime.P95Latency = latencies(history)
+	monitor.Status.Uptime = uptime
+}
+
+// countCheck adds a check to the bucket of its hour and drops buckets older than uptimeRetention
+func countCheck(buckets []monitorv1alpha1.UptimeBucket, now time.Time, up bool) []monitorv1alpha1.UptimeBucket {
+	hour := now.Truncate(time.Hour)
+	cutoff := hour.Add(-uptimeRetention)
+	kept := buckets[:0]
+	for _, bucket := range buckets {
+		if bucket.Hour.Time.After(cutoff) {
+			kept = append(kept, bucket)
+		}
+	}
+
+	if n := len(kept); n == 0 || !kept[n-1].Hour.Time.Equal(hour) {
+		kept = append(kept, monitorv1alpha1.UptimeBucket{Hour: metav1.NewTime(hour)})
+	}
+	current := &kept[len(kept)-1]
+	current.Checks++
+	if up {
+		current.Up++
+	}
+	return kept
+}
+
+// uptimeOfLastHour estimates the uptime of the hour before now from the buckets of the
+// current and the previous hour. The history may hold far less or far more than an hour of
+// checks, so the previous bucket is weighted by the share of it still inside the window,
+// assuming its checks were spread evenly.
+func uptimeOfLastHour(buckets []monitorv1alpha1.UptimeBucket, now time.Time) string {
+	hour := now.Truncate(time.Hour)
+	weight := 1 - float64(now.Sub(hour))/float64(time.Hour)
+	var checks, up float64
+	for _, bucket := range buckets {
+		switch {
+		case bucket.Hour.Time.Equal(hour):
+			checks += float64(bucket.Checks)
+			up += float64(bucket.Up)
+		case bucket.Hour.Time.Equal(hour.Add(-time.Hour)):
+			checks += weight * float64(bucket.Checks)
+			up += weight * float64(bucket.Up)
+		}
+	}
+	if checks == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(100*up/checks, 'f', 2, 64)
+}
+
+// uptimeOfBuckets is the percentage of checks that were up in the buckets whose
+// hour ends after since, so the window is rounded up to whole hours
+func uptimeOfBuckets(buckets []monitorv1alpha1.UptimeBucket, since time.Time) string {
+	var checks, up int
+	for _, bucket := range buckets {
+		if bucket.Hour.Time.Add(time.Hour).After(since) {
+			checks += int(bucket.Checks)
+			up += int(bucket.Up)
+		}
+	}
+	return percentage(up, checks)
+}
+
+// percentage formats up/total with two decimals; it is empty without checks
+func percentage(up, total int) string {
+	if total == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(100*float64(up)/float64(total), 'f', 2, 64)
+}
+
+// latencies returns the average and nearest-rank 95th percentile response time of the history
+func latencies(history []monitorv1alpha1.CheckRecord) (*metav1.Duration, *metav1.Duration) {
+	if len(history) == 0 {
+		return nil, nil
+	}
+	sorted := make([]time.Duration, 0, len(history))
+	var total time.Duration
+	for _, record := range history {
+		sorted = append(sorted, record.ResponseTime.Duration)
+		total += record.ResponseTime.Duration
+	}
+	slices.Sort(sorted)
+	rank := int(math.Ceil(0.95*float64(len(sorted)))) - 1
+	average := (total / time.Duration(len(sorted))).Round(time.Millisecond)
+	return &metav1.Duration{Duration: average}, &metav1.Duration{Duration: sorted[rank]}
+}
@@ -0,0 +1,91 @@
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	Endpointmonitoringv1alpha1 "github.com/LiciousTech/endpoint-monitoring-operator/api/v1alpha1"
+	"github.com/LiciousTech/endpoint-monitoring-operator/internal/driver"
+)
+
+var _ = Describe("Check history", func() {
+	start := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	record := func(monitor *Endpointmonitoringv1alpha1.EndpointMonitor, at time.Time, status string, latency time.Duration) {
+		result := &driver.CheckResult{Success: status != driver.StatusFailure, ResponseTime: latency, Message: "line one\nline two"}
+		recordCheck(monitor, at, status, result)
+	}
+
+	It("should keep a bounded history of short records", func() {
+		limit := int32(5)
+		monitor := &Endpointmonitoringv1alpha1.EndpointMonitor{
+			Spec: Endpointmonitoringv1alpha1.EndpointMonitorSpec{HistoryLimit: &limit},
+		}
+		for i := range 8 {
+			record(monitor, start.Add(time.Duration(i)*time.Minute), driver.StatusSuccess, 100*time.Millisecond)
+		}
+
+		Expect(monitor.Status.History).To(HaveLen(5))
+		Expect(monitor.Status.History[0].Time.Time).To(Equal(start.Add(3 * time.Minute)))
+		Expect(monitor.Status.History[4].Time.Time).To(Equal(start.Add(7 * time.Minute)))
+		Expect(monitor.Status.History[4].Message).To(Equal("line one"))
+	})
+
+	It("should compute rolling uptime and latency percentiles", func() {
+		monitor := &Endpointmonitoringv1alpha1.EndpointMonitor{}
+
+		By("recording a failing hour the day before")
+		for i := range 4 {
+			record(monitor, start.Add(-30*time.Hour+time.Duration(i)*time.Minute), driver.StatusFailure, time.Second)
+		}
+		By("recording the last hour with one failure and one degraded check")
+		for i := range 20 {
+			status := driver.StatusSuccess
+			switch i {
+			case 5:
+				status = driver.StatusFailure
+			case 6:
+				status = driver.StatusDegraded
+			}
+			record(monitor, start.Add(time.Duration(i)*time.Minute), status, time.Duration(i+1)*10*time.Millisecond)
+		}
+
+		uptime := monitor.Status.Uptime
+		Expect(uptime).NotTo(BeNil())
+		Expect(uptime.LastHour).To(Equal("95.00"))
+		Expect(uptime.LastDay).To(Equal("95.00"))
+		Expect(uptime.LastWeek).To(Equal("79.17"))
+		Expect(uptime.HourlyBuckets).To(HaveLen(2))
+		// The 30 entry history still holds the four slow failures of the day before
+		Expect(uptime.P95Latency.Duration).To(Equal(time.Second))
+		Expect(uptime.AverageLatency.Duration).To(Equal(254 * time.Millisecond))
+	})
+
+	It("should weight the previous hour by its share of the last hour", func() {
+		monitor := &Endpointmonitoringv1alpha1.EndpointMonitor{}
+		By("failing every ten minutes during the previous hour")
+		for i := range 6 {
+			record(monitor, start.Add(-time.Hour+time.Duration(i)*10*time.Minute), driver.StatusFailure, time.Second)
+		}
+		By("succeeding half an hour into the current hour")
+		record(monitor, start.Add(30*time.Minute), driver.StatusSuccess, time.Second)
+
+		// Half of the previous hour's six failures count next to the one success
+		Expect(monitor.Status.Uptime.LastHour).To(Equal("25.00"))
+		Expect(monitor.Status.Uptime.LastDay).To(Equal("14.29"))
+	})
+
+	It("should drop hourly buckets older than a week", func() {
+		monitor := &Endpointmonitoringv1alpha1.EndpointMonitor{}
+		record(monitor, start.Add(-8*24*time.Hour), driver.StatusFailure, time.Second)
+		record(monitor, start, driver.StatusSuccess, time.Second)
+
+		Expect(monitor.Status.Uptime.HourlyBuckets).To(Equal([]Endpointmonitoringv1alpha1.UptimeBucket{
+			{Hour: metav1.NewTime(start), Checks: 1, Up: 1},
+		}))
+		Expect(monitor.Status.Uptime.LastWeek).To(Equal("100.00"))
+	})
+})
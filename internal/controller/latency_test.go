@@ -0,0 +1,49 @@
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	Endpointmonitoringv1alpha1 "github.com/LiciousTech/endpoint-monitoring-operator/api/v1alpha1"
+	"github.com/LiciousTech/endpoint-monitoring-operator/internal/driver"
+)
+
+var _ = Describe("Latency thresholds", func() {
+	thresholds := func(warning, critical time.Duration) Endpointmonitoringv1alpha1.EndpointMonitorSpec {
+		return Endpointmonitoringv1alpha1.EndpointMonitorSpec{
+			WarningLatency:  &metav1.Duration{Duration: warning},
+			CriticalLatency: &metav1.Duration{Duration: critical},
+		}
+	}
+
+	DescribeTable("should map a check result to its status",
+		func(spec Endpointmonitoringv1alpha1.EndpointMonitorSpec, result driver.CheckResult, expected, message string) {
+			Expect(evaluateStatus(&spec, &result)).To(Equal(expected))
+			Expect(result.Message).To(ContainSubstring(message))
+		},
+		Entry("a failed check", thresholds(time.Second, 2*time.Second),
+			driver.CheckResult{Success: false, ResponseTime: time.Millisecond, Message: "refused"},
+			driver.StatusFailure, "refused"),
+		Entry("above the critical latency", thresholds(time.Second, 2*time.Second),
+			driver.CheckResult{Success: true, ResponseTime: 3 * time.Second},
+			driver.StatusFailure, "exceeds critical latency 2s"),
+		Entry("above the warning latency", thresholds(time.Second, 2*time.Second),
+			driver.CheckResult{Success: true, ResponseTime: 1500 * time.Millisecond},
+			driver.StatusDegraded, "exceeds warning latency 1s"),
+		Entry("within both thresholds", thresholds(time.Second, 2*time.Second),
+			driver.CheckResult{Success: true, ResponseTime: 500 * time.Millisecond, Message: "ok"},
+			driver.StatusSuccess, "ok"),
+		Entry("a degraded driver result", thresholds(time.Second, 2*time.Second),
+			driver.CheckResult{Success: true, Degraded: true, ResponseTime: time.Millisecond, Message: "replica lagging"},
+			driver.StatusDegraded, "replica lagging"),
+		Entry("zero thresholds", thresholds(0, 0),
+			driver.CheckResult{Success: true, ResponseTime: time.Minute, Message: "ok"},
+			driver.StatusSuccess, "ok"),
+		Entry("unset thresholds", Endpointmonitoringv1alpha1.EndpointMonitorSpec{},
+			driver.CheckResult{Success: true, ResponseTime: time.Minute, Message: "ok"},
+			driver.StatusSuccess, "ok"),
+	)
+})
@@ -0,0 +1,106 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	monitorv1alpha1 "github.com/LiciousTech/endpoint-monitoring-operator/api/v1alpha1"
+)
+
+// AnnotationSilenceUntil mutes a single monitor's notifications until an RFC 3339 timestamp
+const AnnotationSilenceUntil = "endpointmonitor.licious.io/silence-until"
+
+// ConditionMuted is true while a maintenance window or silence holds back notifications
+const ConditionMuted = "Muted"
+
+// mutedReason explains why notifications of monitor are muted at now, or returns "".
+// Invalid windows and silences are logged and ignored rather than failing the check.
+func (r *EndpointMonitorReconciler) mutedReason(ctx context.Context, monitor *monitorv1alpha1.EndpointMonitor, now time.Time) (string, error) {
+	logger := log.FromContext(ctx)
+
+	if value := monitor.Annotations[AnnotationSilenceUntil]; value != "" {
+		until, err := time.Parse(time.RFC3339, value)
+		switch {
+		case err != nil:
+			logger.Info("Ignoring invalid silence annotation", "annotation", AnnotationSilenceUntil, "value", value)
+		case now.Before(until):
+			return fmt.Sprintf("silenced until %s", until.Format(time.RFC3339)), nil
+		}
+	}
+
+	var windows monitorv1alpha1.MaintenanceWindowList
+	if err := r.List(ctx, &windows, client.InNamespace(monitor.Namespace)); err != nil {
+		return "", err
+	}
+	for i := range windows.Items {
+		window := &windows.Items[i]
+		selector, err := metav1.LabelSelectorAsSelector(&window.Spec.Selector)
+		if err != nil {
+			logger.Info("Ignoring maintenance window with invalid selector", "window", window.Name, "error", err.Error())
+			continue
+		}
+		if !selector.Matches(labels.Set(monitor.Labels)) {
+			continue
+		}
+
+		active, err := windowActive(&window.Spec, now)
+		if err != nil {
+			logger.Info("Ignoring invalid maintenance window", "window", window.Name, "error", err.Error())
+			continue
+		}
+		if active {
+			reason := fmt.Sprintf("maintenance window %s", window.Name)
+			if window.Spec.Reason != "" {
+				reason = fmt.Sprintf("%s: %s", reason, window.Spec.Reason)
+			}
+			return reason, nil
+		}
+	}
+	return "", nil
+}
+
+// windowActive reports whether now falls inside the one-off window or inside any
+// recurrence of the scheduled window, that is a schedule tick in (now-duration, now].
+func windowActive(spec *monitorv1alpha1.MaintenanceWindowSpec, now time.Time) (bool, error) {
+	if spec.Schedule == "" {
+		if spec.StartTime == nil || spec.EndTime == nil {
+			return false, fmt.Errorf("either schedule and duration or startTime and endTime are required")
+		}
+		return !now.Before(spec.StartTime.Time) && now.Before(spec.EndTime.Time), nil
+	}
+
+	if spec.Duration == nil || spec.Duration.Duration <= 0 {
+		return false, fmt.Errorf("duration is required with a schedule")
+	}
+	schedule, location, err := parseSchedule(spec.Schedule, spec.TimeZone)
+	if err != nil {
+		return false, err
+	}
+	start := schedule.Next(now.In(location).Add(-spec.Duration.Duration))
+	if start.IsZero() {
+		return false, fmt.Errorf("invalid schedule %q: never fires", spec.Schedule)
+	}
+	return !start.After(now), nil
+}
+
+// setMuted records the active maintenance window or silence; unmuted monitors carry no Muted condition
+func setMuted(monitor *monitorv1alpha1.EndpointMonitor, reason string) {
+	if reason == "" {
+		meta.RemoveStatusCondition(&monitor.Status.Conditions, ConditionMuted)
+		return
+	}
+	meta.SetStatusCondition(&monitor.Status.Conditions, metav1.Condition{
+		Type:               ConditionMuted,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: monitor.Generation,
+		Reason:             "Maintenance",
+		Message:            truncate(reason, maxStatusMessageLength),
+	})
+}
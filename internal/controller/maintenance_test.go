@@ -0,0 +1,92 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	Endpointmonitoringv1alpha1 "github.com/LiciousTech/endpoint-monitoring-operator/api/v1alpha1"
+)
+
+var _ = Describe("Maintenance windows", func() {
+	// Sunday 2025-06-01 02:30 in Asia/Kolkata
+	now := time.Date(2025, 5, 31, 21, 0, 0, 0, time.UTC)
+	at := func(t time.Time) *metav1.Time { mt := metav1.NewTime(t); return &mt }
+
+	DescribeTable("should evaluate the window at a point in time",
+		func(spec Endpointmonitoringv1alpha1.MaintenanceWindowSpec, expected bool) {
+			active, err := windowActive(&spec, now)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(active).To(Equal(expected))
+		},
+		Entry("inside a one-off window", Endpointmonitoringv1alpha1.MaintenanceWindowSpec{
+			StartTime: at(now.Add(-time.Hour)), EndTime: at(now.Add(time.Hour)),
+		}, true),
+		Entry("after a one-off window", Endpointmonitoringv1alpha1.MaintenanceWindowSpec{
+			StartTime: at(now.Add(-2 * time.Hour)), EndTime: at(now.Add(-time.Hour)),
+		}, false),
+		Entry("inside a recurring window in the configured time zone", Endpointmonitoringv1alpha1.MaintenanceWindowSpec{
+			Schedule: "0 2 * * SUN", Duration: &metav1.Duration{Duration: time.Hour}, TimeZone: "Asia/Kolkata",
+		}, true),
+		Entry("the same schedule evaluated in UTC", Endpointmonitoringv1alpha1.MaintenanceWindowSpec{
+			Schedule: "0 2 * * SUN", Duration: &metav1.Duration{Duration: time.Hour},
+		}, false),
+		Entry("after a recurring window ended", Endpointmonitoringv1alpha1.MaintenanceWindowSpec{
+			Schedule: "0 2 * * SUN", Duration: &metav1.Duration{Duration: 30 * time.Minute}, TimeZone: "Asia/Kolkata",
+		}, false),
+	)
+
+	It("should reject incomplete windows", func() {
+		_, err := windowActive(&Endpointmonitoringv1alpha1.MaintenanceWindowSpec{Schedule: "0 2 * * *"}, now)
+		Expect(err).To(MatchError(ContainSubstring("duration is required")))
+		_, err = windowActive(&Endpointmonitoringv1alpha1.MaintenanceWindowSpec{StartTime: at(now)}, now)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject schedules that never fire", func() {
+		spec := &Endpointmonitoringv1alpha1.MaintenanceWindowSpec{
+			Schedule: "0 2 30 2 *",
+			Duration: &metav1.Duration{Duration: time.Hour},
+		}
+		active, err := windowActive(spec, now)
+		Expect(err).To(MatchError(ContainSubstring(`invalid schedule "0 2 30 2 *": never fires`)))
+		Expect(active).To(BeFalse())
+	})
+
+	It("should mute monitors selected by an active window or silenced by annotation", func() {
+		ctx := context.Background()
+		reconciler := &EndpointMonitorReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+
+		window := &Endpointmonitoringv1alpha1.MaintenanceWindow{
+			ObjectMeta: metav1.ObjectMeta{Name: "db-maintenance", Namespace: "default"},
+			Spec: Endpointmonitoringv1alpha1.MaintenanceWindowSpec{
+				Selector:  metav1.LabelSelector{MatchLabels: map[string]string{"tier": "database"}},
+				StartTime: at(now.Add(-time.Hour)),
+				EndTime:   at(now.Add(time.Hour)),
+				Reason:    "failover test",
+			},
+		}
+		Expect(k8sClient.Create(ctx, window)).To(Succeed())
+		DeferCleanup(func() { Expect(k8sClient.Delete(ctx, window)).To(Succeed()) })
+
+		monitor := &Endpointmonitoringv1alpha1.EndpointMonitor{
+			ObjectMeta: metav1.ObjectMeta{Name: "orders-db", Namespace: "default", Labels: map[string]string{"tier": "database"}},
+		}
+		reason, err := reconciler.mutedReason(ctx, monitor, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reason).To(Equal("maintenance window db-maintenance: failover test"))
+
+		monitor.Labels = nil
+		monitor.Annotations = map[string]string{AnnotationSilenceUntil: now.Add(time.Minute).Format(time.RFC3339)}
+		reason, err = reconciler.mutedReason(ctx, monitor, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reason).To(HavePrefix("silenced until "))
+
+		reason, err = reconciler.mutedReason(ctx, monitor, now.Add(time.Hour))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reason).To(BeEmpty())
+	})
+})
@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	Endpointmonitoringv1alpha1 "github.com/LiciousTech/endpoint-monitoring-operator/api/v1alpha1"
+	"github.com/LiciousTech/endpoint-monitoring-operator/internal/driver"
+)
+
+var _ = Describe("Run-now trigger", func() {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "on-demand", Namespace: "default"}
+	lastChecked := metav1.NewTime(time.Now().Add(-time.Minute).Truncate(time.Second))
+
+	BeforeEach(func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		DeferCleanup(server.Close)
+
+		monitor := &Endpointmonitoringv1alpha1.EndpointMonitor{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+			Spec: Endpointmonitoringv1alpha1.EndpointMonitorSpec{
+				Driver:   "http",
+				Endpoint: server.URL,
+				Interval: &metav1.Duration{Duration: 10 * time.Minute},
+				Notify: Endpointmonitoringv1alpha1.NotifyConfig{
+					Slack: &Endpointmonitoringv1alpha1.SlackConfig{Enabled: true, WebhookURL: server.URL},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, monitor)).To(Succeed())
+		monitor.Status.LastCheckedTime = lastChecked
+		monitor.Status.LastStatus = driver.StatusFailure
+		Expect(k8sClient.Status().Update(ctx, monitor)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.Delete(ctx, &Endpointmonitoringv1alpha1.EndpointMonitor{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+		})).To(Succeed())
+	})
+
+	It("should check once per trigger without moving the schedule", func() {
+		reconciler := &EndpointMonitorReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+
+		By("reconciling before the next check is due")
+		result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		scheduled := result.RequeueAfter
+		Expect(scheduled).To(BeNumerically(">", 0))
+
+		monitor := &Endpointmonitoringv1alpha1.EndpointMonitor{}
+		Expect(k8sClient.Get(ctx, key, monitor)).To(Succeed())
+		Expect(monitor.Status.LastStatus).To(Equal(driver.StatusFailure))
+
+		By("annotating the monitor with a run-now trigger")
+		monitor.Annotations = map[string]string{AnnotationRunNow: "2025-06-01T12:00:00Z"}
+		Expect(k8sClient.Update(ctx, monitor)).To(Succeed())
+
+		result, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeNumerically("~", scheduled, 5*time.Second))
+
+		Expect(k8sClient.Get(ctx, key, monitor)).To(Succeed())
+		Expect(monitor.Status.LastStatus).To(Equal(driver.StatusSuccess))
+		Expect(monitor.Status.LastRunNow).To(Equal("2025-06-01T12:00:00Z"))
+		Expect(monitor.Status.LastCheckedTime.Equal(&lastChecked)).To(BeTrue())
+
+		By("reconciling again with the handled trigger")
+		monitor.Status.LastStatus = driver.StatusFailure
+		Expect(k8sClient.Status().Update(ctx, monitor)).To(Succeed())
+		_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(k8sClient.Get(ctx, key, monitor)).To(Succeed())
+		Expect(monitor.Status.LastStatus).To(Equal(driver.StatusFailure))
+	})
+})
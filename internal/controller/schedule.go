@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	monitorv1alpha1 "github.com/LiciousTech/endpoint-monitoring-operator/api/v1alpha1"
+)
+
+// nextCheckTime returns when monitor is due next; a zero time means right away. Scheduled
+// monitors wait for the first tick after creation. Interval monitors check immediately the
+// first time and then on a per-monitor grid of the interval, see jitterOffset.
+func nextCheckTime(monitor *monitorv1alpha1.EndpointMonitor) (time.Time, error) {
+	last := monitor.Status.LastCheckedTime.Time
+
+	if monitor.Spec.Schedule != "" {
+		schedule, location, err := parseSchedule(monitor.Spec.Schedule, monitor.Spec.TimeZone)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if last.IsZero() {
+			last = monitor.CreationTimestamp.Time
+		}
+		next := schedule.Next(last.In(location))
+		if next.IsZero() {
+			// cron returns the zero time for schedules that never fire, such as February 30th
+			return time.Time{}, fmt.Errorf("invalid schedule %q: never fires", monitor.Spec.Schedule)
+		}
+		return next, nil
+	}
+
+	interval := checkInterval(&monitor.Spec)
+	if interval <= 0 {
+		return time.Time{}, fmt.Errorf("one of interval, checkInterval or schedule is required")
+	}
+	if last.IsZero() {
+		return time.Time{}, nil
+	}
+	// The status keeps whole seconds only, so a slot less than half an interval after the
+	// last check is the one that check already ran in
+	return nextSlot(last.Add(interval/2), interval, jitterOffset(monitor, interval)), nil
+}
+
+// checkInterval returns spec.interval, falling back to checkInterval seconds
+func checkInterval(spec *monitorv1alpha1.EndpointMonitorSpec) time.Duration {
+	if spec.Interval != nil {
+		return spec.Interval.Duration
+	}
+	return time.Duration(spec.CheckInterval) * time.Second
+}
+
+// jitterOffset is the monitor's fixed phase within its interval. It is derived from the
+// namespaced name, so it survives restarts and spreads monitors evenly across the interval.
+func jitterOffset(monitor *monitorv1alpha1.EndpointMonitor, interval time.Duration) time.Duration {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(monitor.Namespace + "/" + monitor.Name))
+	return time.Duration(h.Sum64() % uint64(interval))
+}
+
+// nextSlot returns the first time after t of the form offset + k*interval since the Unix epoch
+func nextSlot(t time.Time, interval, offset time.Duration) time.Time {
+	k := (t.UnixNano()-int64(offset))/int64(interval) + 1
+	return time.Unix(0, k*int64(interval)+int64(offset))
+}
+
+// parseSchedule parses a standard five-field cron expression evaluated in the given time zone
+func parseSchedule(expression, timeZone string) (cron.Schedule, *time.Location, error) {
+	schedule, err := cron.ParseStandard(expression)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid schedule %q: %w", expression, err)
+	}
+	location := time.UTC
+	if timeZone != "" {
+		if location, err = time.LoadLocation(timeZone); err != nil {
+			return nil, nil, fmt.Errorf("invalid time zone %q: %w", timeZone, err)
+		}
+	}
+	return schedule, location, nil
+}
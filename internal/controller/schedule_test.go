@@ -0,0 +1,124 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	Endpointmonitoringv1alpha1 "github.com/LiciousTech/endpoint-monitoring-operator/api/v1alpha1"
+)
+
+var _ = Describe("Check scheduling", func() {
+	newMonitor := func(name string, spec Endpointmonitoringv1alpha1.EndpointMonitorSpec, last time.Time) *Endpointmonitoringv1alpha1.EndpointMonitor {
+		return &Endpointmonitoringv1alpha1.EndpointMonitor{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "default",
+				CreationTimestamp: metav1.NewTime(time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)),
+			},
+			Spec:   spec,
+			Status: Endpointmonitoringv1alpha1.EndpointMonitorStatus{LastCheckedTime: metav1.NewTime(last)},
+		}
+	}
+	last := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	It("should check new interval monitors immediately", func() {
+		next, err := nextCheckTime(newMonitor("fresh", Endpointmonitoringv1alpha1.EndpointMonitorSpec{CheckInterval: 60}, time.Time{}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(next.IsZero()).To(BeTrue())
+	})
+
+	It("should keep each monitor on a stable slot of its interval", func() {
+		spec := Endpointmonitoringv1alpha1.EndpointMonitorSpec{Interval: &metav1.Duration{Duration: 90 * time.Second}}
+		monitor := newMonitor("checkout", spec, last)
+
+		next, err := nextCheckTime(monitor)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(next.Sub(last)).To(BeNumerically(">=", 45*time.Second))
+		Expect(next.Sub(last)).To(BeNumerically("<=", 135*time.Second))
+
+		monitor.Status.LastCheckedTime = metav1.NewTime(next)
+		following, err := nextCheckTime(monitor)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(following.Sub(next)).To(Equal(90 * time.Second))
+	})
+
+	It("should spread monitors created together across the interval", func() {
+		interval := time.Minute
+		offsets := map[time.Duration]bool{}
+		var minOffset, maxOffset time.Duration = interval, 0
+		for i := range 50 {
+			offset := jitterOffset(newMonitor(fmt.Sprintf("service-%d", i), Endpointmonitoringv1alpha1.EndpointMonitorSpec{}, last), interval)
+			offsets[offset] = true
+			minOffset, maxOffset = min(minOffset, offset), max(maxOffset, offset)
+		}
+		Expect(len(offsets)).To(BeNumerically(">", 45))
+		Expect(maxOffset - minOffset).To(BeNumerically(">", 45*time.Second))
+	})
+
+	It("should follow a cron schedule in its time zone", func() {
+		spec := Endpointmonitoringv1alpha1.EndpointMonitorSpec{Schedule: "30 9 * * *", TimeZone: "Asia/Kolkata"}
+
+		next, err := nextCheckTime(newMonitor("nightly", spec, last))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(next.UTC()).To(Equal(time.Date(2025, 6, 2, 4, 0, 0, 0, time.UTC)))
+
+		next, err = nextCheckTime(newMonitor("nightly", spec, time.Time{}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(next.UTC()).To(Equal(time.Date(2025, 6, 2, 4, 0, 0, 0, time.UTC)))
+	})
+
+	It("should reject monitors without a schedule", func() {
+		_, err := nextCheckTime(newMonitor("unscheduled", Endpointmonitoringv1alpha1.EndpointMonitorSpec{}, last))
+		Expect(err).To(MatchError(ContainSubstring("one of interval, checkInterval or schedule is required")))
+		_, err = nextCheckTime(newMonitor("bad", Endpointmonitoringv1alpha1.EndpointMonitorSpec{Schedule: "every day"}, last))
+		Expect(err).To(MatchError(ContainSubstring(`invalid schedule "every day"`)))
+		_, err = nextCheckTime(newMonitor("leap", Endpointmonitoringv1alpha1.EndpointMonitorSpec{Schedule: "0 0 30 2 *"}, last))
+		Expect(err).To(MatchError(ContainSubstring(`invalid schedule "0 0 30 2 *": never fires`)))
+	})
+
+	It("should report an invalid time zone without requeueing", func() {
+		ctx := context.Background()
+		key := types.NamespacedName{Name: "misscheduled", Namespace: "default"}
+		monitor := &Endpointmonitoringv1alpha1.EndpointMonitor{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+			Spec: Endpointmonitoringv1alpha1.EndpointMonitorSpec{
+				Driver:   "http",
+				Endpoint: "http://misscheduled.default.svc",
+				Schedule: "0 * * * *",
+				TimeZone: "Mars/Olympus_Mons",
+			},
+		}
+		Expect(k8sClient.Create(ctx, monitor)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(k8sClient.Delete(ctx, monitor)).To(Succeed())
+		})
+		reconciler := &EndpointMonitorReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+
+		result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(reconcile.Result{}))
+		Expect(k8sClient.Get(ctx, key, monitor)).To(Succeed())
+		cond := meta.FindStatusCondition(monitor.Status.Conditions, ConditionReady)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+		Expect(cond.Reason).To(Equal("InvalidSchedule"))
+		Expect(cond.Message).To(ContainSubstring(`invalid time zone "Mars/Olympus_Mons"`))
+
+		By("fixing the time zone")
+		monitor.Spec.TimeZone = "Europe/Berlin"
+		Expect(k8sClient.Update(ctx, monitor)).To(Succeed())
+		result, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+		Expect(k8sClient.Get(ctx, key, monitor)).To(Succeed())
+		Expect(meta.IsStatusConditionTrue(monitor.Status.Conditions, ConditionReady)).To(BeTrue())
+	})
+})
@@ -0,0 +1,69 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	Endpointmonitoringv1alpha1 "github.com/LiciousTech/endpoint-monitoring-operator/api/v1alpha1"
+)
+
+var _ = Describe("Suspended monitors", func() {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "paused", Namespace: "default"}
+
+	BeforeEach(func() {
+		monitor := &Endpointmonitoringv1alpha1.EndpointMonitor{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+			Spec: Endpointmonitoringv1alpha1.EndpointMonitorSpec{
+				Driver:        "http",
+				Endpoint:      "http://paused.default.svc",
+				CheckInterval: 300,
+				Suspend:       true,
+			},
+		}
+		Expect(k8sClient.Create(ctx, monitor)).To(Succeed())
+		monitor.Status.LastCheckedTime = metav1.NewTime(time.Now().Add(-time.Minute))
+		Expect(k8sClient.Status().Update(ctx, monitor)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.Delete(ctx, &Endpointmonitoringv1alpha1.EndpointMonitor{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+		})).To(Succeed())
+	})
+
+	It("should stop checks while suspended and resume on the original interval", func() {
+		reconciler := &EndpointMonitorReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+
+		result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(reconcile.Result{}))
+
+		monitor := &Endpointmonitoringv1alpha1.EndpointMonitor{}
+		Expect(k8sClient.Get(ctx, key, monitor)).To(Succeed())
+		Expect(meta.IsStatusConditionTrue(monitor.Status.Conditions, ConditionSuspended)).To(BeTrue())
+
+		By("resuming the monitor")
+		monitor.Spec.Suspend = false
+		Expect(k8sClient.Update(ctx, monitor)).To(Succeed())
+
+		result, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		// The next check lands on the monitor's slot, half to one and a half intervals after the last one
+		Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+		Expect(result.RequeueAfter).To(BeNumerically("<=", 6*time.Minute+30*time.Second))
+
+		Expect(k8sClient.Get(ctx, key, monitor)).To(Succeed())
+		cond := meta.FindStatusCondition(monitor.Status.Conditions, ConditionSuspended)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+		Expect(cond.Reason).To(Equal("Resumed"))
+	})
+})
@@ -1,44 +1,677 @@
 package driver
 
 import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
 	"fmt"
+	"io"
+	"math/rand/v2"
 	"net"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+
+	v1 "github.com/LiciousTech/endpoint-monitoring-operator/api/v1alpha1"
 )
 
+const defaultDNSTimeout = 5 * time.Second
+
+// resolvConfPath is the resolver configuration used when no nameservers are configured
+var resolvConfPath = "/etc/resolv.conf"
+
+var dnsRecordTypes = map[string]dnsmessage.Type{
+	"A":     dnsmessage.TypeA,
+	"AAAA":  dnsmessage.TypeAAAA,
+	"CNAME": dnsmessage.TypeCNAME,
+	"MX":    dnsmessage.TypeMX,
+	"TXT":   dnsmessage.TypeTXT,
+	"SRV":   dnsmessage.TypeSRV,
+	"NS":    dnsmessage.TypeNS,
+}
+
+var dnsRcodes = map[string]dnsmessage.RCode{
+	"NOERROR":  dnsmessage.RCodeSuccess,
+	"FORMERR":  dnsmessage.RCodeFormatError,
+	"SERVFAIL": dnsmessage.RCodeServerFailure,
+	"NXDOMAIN": dnsmessage.RCodeNameError,
+	"NOTIMP":   dnsmessage.RCodeNotImplemented,
+	"REFUSED":  dnsmessage.RCodeRefused,
+}
+
 type DNSDriver struct {
-	endpoint string
+	endpoint        string
+	recordType      string
+	qtypes          []dnsmessage.Type // A and AAAA when no record type is set
+	system          bool              // resolve like the host does, including /etc/hosts and IP literals
+	nameservers     []string
+	protocol        string
+	timeout         time.Duration
+	expectedRcode   dnsmessage.RCode
+	expectedAnswers []string
+	pattern         *regexp.Regexp
+	minTTL          *int32
+	maxTTL          *int32
+	compare         bool
+}
+
+// dnsResponse is the parsed answer of a single nameserver
+type dnsResponse struct {
+	nameserver string
+	rcode      dnsmessage.RCode
+	answers    []string // normalized and sorted
+	minTTL     uint32
+	maxTTL     uint32
+	duration   time.Duration
 }
 
-func NewDNSDriver(endpoint string) (Driver, error) {
+func NewDNSDriver(endpoint string, check *v1.DnsCheck) (Driver, error) {
 	if endpoint == "" {
 		return nil, fmt.Errorf("endpoint cannot be empty")
 	}
+	if check == nil {
+		check = &v1.DnsCheck{}
+	}
+
+	d := &DNSDriver{
+		endpoint:      endpoint,
+		recordType:    strings.ToUpper(check.RecordType),
+		protocol:      strings.ToLower(check.Protocol),
+		timeout:       defaultDNSTimeout,
+		expectedRcode: dnsmessage.RCodeSuccess,
+		minTTL:        check.MinTTL,
+		maxTTL:        check.MaxTTL,
+		compare:       check.CompareResolvers,
+	}
+
+	if d.recordType == "" {
+		d.recordType = "A/AAAA"
+		d.qtypes = []dnsmessage.Type{dnsmessage.TypeA, dnsmessage.TypeAAAA}
+		// Without DNS-level assertions the host resolver answers, as it always has for this driver
+		d.system = len(check.Nameservers) == 0 && check.Protocol == "" && check.ExpectedRcode == "" &&
+			check.MinTTL == nil && check.MaxTTL == nil && !check.CompareResolvers
+	} else {
+		qtype, ok := dnsRecordTypes[d.recordType]
+		if !ok {
+			return nil, fmt.Errorf("unsupported DNS record type %q", check.RecordType)
+		}
+		d.qtypes = []dnsmessage.Type{qtype}
+	}
+
+	if d.protocol == "" {
+		d.protocol = "udp"
+	}
+	defaultPort := "53"
+	switch d.protocol {
+	case "udp", "tcp":
+	case "tls":
+		defaultPort = "853"
+	default:
+		return nil, fmt.Errorf("unsupported DNS protocol %q, expected udp, tcp or tls", check.Protocol)
+	}
+
+	for _, ns := range check.Nameservers {
+		d.nameservers = append(d.nameservers, withDefaultPort(ns, defaultPort))
+	}
+	if d.compare && len(d.nameservers) < 2 {
+		return nil, fmt.Errorf("compareResolvers requires at least two nameservers")
+	}
+
+	if check.Timeout != nil && check.Timeout.Duration > 0 {
+		d.timeout = check.Timeout.Duration
+	}
+
+	if check.ExpectedRcode != "" {
+		rcode, ok := dnsRcodes[strings.ToUpper(check.ExpectedRcode)]
+		if !ok {
+			return nil, fmt.Errorf("unsupported DNS response code %q", check.ExpectedRcode)
+		}
+		d.expectedRcode = rcode
+	}
 
-	return &DNSDriver{endpoint: endpoint}, nil
+	for _, answer := range check.ExpectedAnswers {
+		d.expectedAnswers = append(d.expectedAnswers, normalizeDNSAnswer(d.qtypes[0], answer))
+	}
+	sort.Strings(d.expectedAnswers)
+
+	if check.AnswerPattern != "" {
+		pattern, err := regexp.Compile(check.AnswerPattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid answerPattern: %w", err)
+		}
+		d.pattern = pattern
+	}
+
+	return d, nil
 }
 
 func (d *DNSDriver) Check() (*CheckResult, error) {
 	start := time.Now()
+	result := &CheckResult{}
 
-	_, err := net.LookupHost(d.endpoint)
-	duration := time.Since(start)
-
-	result := &CheckResult{
-		ResponseTime: duration,
+	if d.system {
+		resp, err := d.lookupHost()
+		if err != nil {
+			result.ResponseTime = time.Since(start)
+			result.Success = false
+			result.Error = err
+			result.Message = fmt.Sprintf("DNS check failed: %v", err)
+			return result, nil
+		}
+		return d.report(result, []*dnsResponse{resp}), nil
 	}
 
+	nameservers, names, err := d.resolverConfig()
 	if err != nil {
+		result.ResponseTime = time.Since(start)
 		result.Success = false
 		result.Error = err
 		result.Message = fmt.Sprintf("DNS check failed: %v", err)
 		return result, nil
 	}
 
+	var responses []*dnsResponse
+	if d.compare {
+		for _, ns := range nameservers {
+			resp, err := d.resolve(ns, names)
+			if err != nil {
+				result.ResponseTime = time.Since(start)
+				result.Success = false
+				result.Error = err
+				result.Message = fmt.Sprintf("DNS check failed querying %s: %v", ns, err)
+				return result, nil
+			}
+			responses = append(responses, resp)
+		}
+	} else {
+		// Like a stub resolver, move on to the next nameserver only on transport errors
+		var lastErr error
+		for _, ns := range nameservers {
+			resp, err := d.resolve(ns, names)
+			if err != nil {
+				lastErr = fmt.Errorf("%s: %w", ns, err)
+				continue
+			}
+			responses = append(responses, resp)
+			break
+		}
+		if len(responses) == 0 {
+			result.ResponseTime = time.Since(start)
+			result.Success = false
+			result.Error = lastErr
+			result.Message = fmt.Sprintf("DNS check failed: %v", lastErr)
+			return result, nil
+		}
+	}
+
+	return d.report(result, responses), nil
+}
+
+// lookupHost resolves the endpoint with the host resolver
+func (d *DNSDriver) lookupHost() (*dnsResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), d.timeout)
+	defer cancel()
+
+	start := time.Now()
+	addrs, err := net.DefaultResolver.LookupHost(ctx, d.endpoint)
+	if err != nil {
+		return nil, err
+	}
+	resp := &dnsResponse{nameserver: "system resolver", rcode: dnsmessage.RCodeSuccess, duration: time.Since(start)}
+	for _, addr := range addrs {
+		resp.answers = append(resp.answers, normalizeDNSAnswer(dnsmessage.TypeA, addr))
+	}
+	sort.Strings(resp.answers)
+	return resp, nil
+}
+
+// report evaluates the responses into result
+func (d *DNSDriver) report(result *CheckResult, responses []*dnsResponse) *CheckResult {
+	var failures []string
+	for _, resp := range responses {
+		if resp.duration > result.ResponseTime {
+			result.ResponseTime = resp.duration
+		}
+		failures = append(failures, d.evaluate(resp)...)
+	}
+	if d.compare {
+		failures = append(failures, compareDNSResponses(responses)...)
+	}
+
+	primary := responses[0]
+	result.Details = map[string]interface{}{
+		"recordType": d.recordType,
+		"nameserver": primary.nameserver,
+		"rcode":      rcodeName(primary.rcode),
+		"answers":    primary.answers,
+		"minTTL":     primary.minTTL,
+		"maxTTL":     primary.maxTTL,
+	}
+
+	summary := make([]string, 0, len(responses))
+	for _, resp := range responses {
+		summary = append(summary, fmt.Sprintf("%s: %s %v ttl %d", resp.nameserver, rcodeName(resp.rcode), resp.answers, resp.minTTL))
+	}
+
+	if len(failures) > 0 {
+		result.Success = false
+		result.Message = fmt.Sprintf("DNS check failed for %s %s: %s (%s, response time: %v)",
+			d.recordType, d.endpoint, strings.Join(failures, "; "), strings.Join(summary, ", "), result.ResponseTime)
+		return result
+	}
+
 	result.Success = true
-	result.Message = fmt.Sprintf("DNS check successful (response time: %v)", duration)
+	result.Message = fmt.Sprintf("DNS check successful for %s %s (%s, response time: %v)",
+		d.recordType, d.endpoint, strings.Join(summary, ", "), result.ResponseTime)
+	return result
+}
+
+// evaluate returns the assertions that a single response violates
+func (d *DNSDriver) evaluate(resp *dnsResponse) []string {
+	var failures []string
+
+	if resp.rcode != d.expectedRcode {
+		failures = append(failures, fmt.Sprintf("%s returned %s, expected %s",
+			resp.nameserver, rcodeName(resp.rcode), rcodeName(d.expectedRcode)))
+		return failures
+	}
+	if d.expectedRcode != dnsmessage.RCodeSuccess {
+		return failures
+	}
+
+	if len(resp.answers) == 0 {
+		return append(failures, fmt.Sprintf("%s returned no %s records", resp.nameserver, d.recordType))
+	}
+
+	if len(d.expectedAnswers) > 0 && !slices.Equal(resp.answers, d.expectedAnswers) {
+		failures = append(failures, fmt.Sprintf("%s answered %v, expected %v", resp.nameserver, resp.answers, d.expectedAnswers))
+	}
+
+	if d.pattern != nil {
+		for _, answer := range resp.answers {
+			if !d.pattern.MatchString(answer) {
+				failures = append(failures, fmt.Sprintf("%s answer %q does not match /%s/", resp.nameserver, answer, d.pattern))
+			}
+		}
+	}
+
+	if d.minTTL != nil && int64(resp.minTTL) < int64(*d.minTTL) {
+		failures = append(failures, fmt.Sprintf("%s TTL %d is below %d", resp.nameserver, resp.minTTL, *d.minTTL))
+	}
+	if d.maxTTL != nil && int64(resp.maxTTL) > int64(*d.maxTTL) {
+		failures = append(failures, fmt.Sprintf("%s TTL %d is above %d", resp.nameserver, resp.maxTTL, *d.maxTTL))
+	}
+
+	return failures
+}
+
+// compareDNSResponses reports nameservers whose answers differ from the first one
+func compareDNSResponses(responses []*dnsResponse) []string {
+	var failures []string
+	reference := responses[0]
+	for _, resp := range responses[1:] {
+		if resp.rcode != reference.rcode || !slices.Equal(resp.answers, reference.answers) {
+			failures = append(failures, fmt.Sprintf("resolvers disagree: %s answered %s %v, %s answered %s %v",
+				reference.nameserver, rcodeName(reference.rcode), reference.answers,
+				resp.nameserver, rcodeName(resp.rcode), resp.answers))
+		}
+	}
+	return failures
+}
+
+// resolverConfig returns the nameservers to query and the candidate names to try.
+// With the system configuration, search domains are applied like the libc resolver does.
+func (d *DNSDriver) resolverConfig() ([]string, []string, error) {
+	fqdn := strings.TrimSuffix(d.endpoint, ".") + "."
+	if len(d.nameservers) > 0 {
+		return d.nameservers, []string{fqdn}, nil
+	}
+
+	servers, search, ndots, err := readResolvConf(resolvConfPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	port := "53"
+	if d.protocol == "tls" {
+		port = "853"
+	}
+	for i := range servers {
+		servers[i] = withDefaultPort(servers[i], port)
+	}
+
+	if strings.HasSuffix(d.endpoint, ".") || strings.Count(d.endpoint, ".") >= ndots {
+		return servers, []string{fqdn}, nil
+	}
+	names := make([]string, 0, len(search)+1)
+	for _, domain := range search {
+		names = append(names, strings.TrimSuffix(d.endpoint, ".")+"."+strings.TrimSuffix(domain, ".")+".")
+	}
+	return servers, append(names, fqdn), nil
+}
+
+// resolve queries ns for each candidate name and returns the first positive answer,
+// or the response for the last candidate when none of them resolve.
+func (d *DNSDriver) resolve(ns string, names []string) (*dnsResponse, error) {
+	var resp *dnsResponse
+	for _, name := range names {
+		var err error
+		resp, err = d.query(ns, name)
+		if err != nil {
+			return nil, err
+		}
+		if resp.rcode == dnsmessage.RCodeSuccess && len(resp.answers) > 0 {
+			break
+		}
+	}
+	return resp, nil
+}
+
+// query asks ns for every record type of the check and merges the answers
+func (d *DNSDriver) query(ns, name string) (*dnsResponse, error) {
+	var merged *dnsResponse
+	for _, qtype := range d.qtypes {
+		resp, err := d.queryType(ns, name, qtype)
+		if err != nil {
+			return nil, err
+		}
+		if merged == nil {
+			merged = resp
+			continue
+		}
+		merged.duration += resp.duration
+		if len(resp.answers) == 0 {
+			continue
+		}
+		if len(merged.answers) == 0 {
+			merged.rcode, merged.minTTL, merged.maxTTL = resp.rcode, resp.minTTL, resp.maxTTL
+		} else {
+			merged.minTTL, merged.maxTTL = min(merged.minTTL, resp.minTTL), max(merged.maxTTL, resp.maxTTL)
+		}
+		merged.answers = append(merged.answers, resp.answers...)
+		sort.Strings(merged.answers)
+	}
+	return merged, nil
+}
+
+func (d *DNSDriver) queryType(ns, name string, qtype dnsmessage.Type) (*dnsResponse, error) {
+	qname, err := dnsmessage.NewName(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DNS name %q: %w", name, err)
+	}
+
+	id := uint16(rand.Uint32())
+	query := dnsmessage.Message{
+		Header:    dnsmessage.Header{ID: id, RecursionDesired: true},
+		Questions: []dnsmessage.Question{{Name: qname, Type: qtype, Class: dnsmessage.ClassINET}},
+	}
+	packet, err := query.Pack()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build DNS query: %w", err)
+	}
+
+	start := time.Now()
+	var raw []byte
+	switch d.protocol {
+	case "udp":
+		raw, err = d.exchangeUDP(ns, packet)
+		// Retry over TCP when the answer does not fit in a datagram
+		if err == nil && len(raw) > 2 && raw[2]&0x02 != 0 {
+			raw, err = d.exchangeStream(ns, packet, false)
+		}
+	case "tcp":
+		raw, err = d.exchangeStream(ns, packet, false)
+	case "tls":
+		raw, err = d.exchangeStream(ns, packet, true)
+	}
+	if err != nil {
+		return nil, err
+	}
 
-	return result, nil
+	resp, err := parseDNSResponse(raw, id, qtype)
+	if err != nil {
+		return nil, err
+	}
+	resp.nameserver = ns
+	resp.duration = time.Since(start)
+	return resp, nil
+}
+
+func (d *DNSDriver) exchangeUDP(ns string, packet []byte) ([]byte, error) {
+	conn, err := net.DialTimeout("udp", ns, d.timeout)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if err := conn.SetDeadline(time.Now().Add(d.timeout)); err != nil {
+		return nil, err
+	}
+	if _, err := conn.Write(packet); err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, 4096)
+	n, err := conn.Read(buf)
+	if err != nil {
+		return nil, err
+	}
+	return buf[:n], nil
+}
+
+// exchangeStream sends packet over TCP, or TLS when useTLS is set, using the two-byte length framing
+func (d *DNSDriver) exchangeStream(ns string, packet []byte, useTLS bool) ([]byte, error) {
+	dialer := &net.Dialer{Timeout: d.timeout}
+
+	var conn net.Conn
+	var err error
+	if useTLS {
+		host, _, _ := net.SplitHostPort(ns)
+		conn, err = tls.DialWithDialer(dialer, "tcp", ns, &tls.Config{ServerName: host, MinVersion: tls.VersionTLS12})
+	} else {
+		conn, err = dialer.Dial("tcp", ns)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if err := conn.SetDeadline(time.Now().Add(d.timeout)); err != nil {
+		return nil, err
+	}
+
+	framed := make([]byte, 2+len(packet))
+	binary.BigEndian.PutUint16(framed, uint16(len(packet)))
+	copy(framed[2:], packet)
+	if _, err := conn.Write(framed); err != nil {
+		return nil, err
+	}
+
+	var length [2]byte
+	if _, err := io.ReadFull(conn, length[:]); err != nil {
+		return nil, err
+	}
+	buf := make([]byte, binary.BigEndian.Uint16(length[:]))
+	if _, err := io.ReadFull(conn, buf); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// parseDNSResponse extracts the answers of type qtype from a raw DNS message
+func parseDNSResponse(raw []byte, id uint16, qtype dnsmessage.Type) (*dnsResponse, error) {
+	var p dnsmessage.Parser
+	header, err := p.Start(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DNS response: %w", err)
+	}
+	if header.ID != id {
+		return nil, fmt.Errorf("DNS response ID mismatch")
+	}
+	if err := p.SkipAllQuestions(); err != nil {
+		return nil, fmt.Errorf("invalid DNS response: %w", err)
+	}
+
+	resp := &dnsResponse{rcode: header.RCode}
+	for {
+		rh, err := p.AnswerHeader()
+		if err == dnsmessage.ErrSectionDone {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid DNS answer: %w", err)
+		}
+		if rh.Type != qtype {
+			if err := p.SkipAnswer(); err != nil {
+				return nil, fmt.Errorf("invalid DNS answer: %w", err)
+			}
+			continue
+		}
+
+		answer, err := parseDNSAnswer(&p, rh.Type)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s answer: %w", rh.Type, err)
+		}
+		resp.answers = append(resp.answers, normalizeDNSAnswer(qtype, answer))
+
+		if len(resp.answers) == 1 || rh.TTL < resp.minTTL {
+			resp.minTTL = rh.TTL
+		}
+		if rh.TTL > resp.maxTTL {
+			resp.maxTTL = rh.TTL
+		}
+	}
+
+	sort.Strings(resp.answers)
+	return resp, nil
+}
+
+func parseDNSAnswer(p *dnsmessage.Parser, qtype dnsmessage.Type) (string, error) {
+	switch qtype {
+	case dnsmessage.TypeA:
+		r, err := p.AResource()
+		if err != nil {
+			return "", err
+		}
+		return net.IP(r.A[:]).String(), nil
+	case dnsmessage.TypeAAAA:
+		r, err := p.AAAAResource()
+		if err != nil {
+			return "", err
+		}
+		return net.IP(r.AAAA[:]).String(), nil
+	case dnsmessage.TypeCNAME:
+		r, err := p.CNAMEResource()
+		if err != nil {
+			return "", err
+		}
+		return r.CNAME.String(), nil
+	case dnsmessage.TypeMX:
+		r, err := p.MXResource()
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%d %s", r.Pref, r.MX.String()), nil
+	case dnsmessage.TypeTXT:
+		r, err := p.TXTResource()
+		if err != nil {
+			return "", err
+		}
+		return strings.Join(r.TXT, ""), nil
+	case dnsmessage.TypeSRV:
+		r, err := p.SRVResource()
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%d %d %d %s", r.Priority, r.Weight, r.Port, r.Target.String()), nil
+	case dnsmessage.TypeNS:
+		r, err := p.NSResource()
+		if err != nil {
+			return "", err
+		}
+		return r.NS.String(), nil
+	}
+	return "", fmt.Errorf("unsupported record type")
+}
+
+// normalizeDNSAnswer makes answers comparable: names are lower-cased without the
+// trailing dot and IP addresses use their canonical form. TXT data is kept verbatim.
+func normalizeDNSAnswer(qtype dnsmessage.Type, answer string) string {
+	answer = strings.TrimSpace(answer)
+	if qtype == dnsmessage.TypeTXT {
+		return answer
+	}
+	if ip := net.ParseIP(answer); ip != nil {
+		return ip.String()
+	}
+
+	fields := strings.Fields(strings.ToLower(answer))
+	for i, field := range fields {
+		fields[i] = strings.TrimSuffix(field, ".")
+	}
+	return strings.Join(fields, " ")
+}
+
+// readResolvConf returns the nameservers, search domains and ndots option of a resolv.conf file
+func readResolvConf(path string) ([]string, []string, int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("no nameservers configured and %s is unreadable: %w", path, err)
+	}
+	defer f.Close()
+
+	var servers, search []string
+	ndots := 1
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") || strings.HasPrefix(fields[0], ";") {
+			continue
+		}
+		switch fields[0] {
+		case "nameserver":
+			servers = append(servers, fields[1])
+		case "search", "domain":
+			search = fields[1:]
+		case "options":
+			for _, option := range fields[1:] {
+				if value, ok := strings.CutPrefix(option, "ndots:"); ok {
+					if n, err := strconv.Atoi(value); err == nil {
+						ndots = n
+					}
+				}
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, 0, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if len(servers) == 0 {
+		return nil, nil, 0, fmt.Errorf("no nameservers found in %s", path)
+	}
+	return servers, search, ndots, nil
+}
+
+func withDefaultPort(host, port string) string {
+	if _, _, err := net.SplitHostPort(host); err == nil {
+		return host
+	}
+	return net.JoinHostPort(strings.Trim(host, "[]"), port)
+}
+
+func rcodeName(rcode dnsmessage.RCode) string {
+	for name, code := range dnsRcodes {
+		if code == rcode {
+			return name
+		}
+	}
+	return strconv.Itoa(int(rcode))
 }
 
 func (d *DNSDriver) GetEndpoint() string {
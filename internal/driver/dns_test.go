@@ -0,0 +1,243 @@
+package driver
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/dns/dnsmessage"
+
+	v1 "github.com/LiciousTech/endpoint-monitoring-operator/api/v1alpha1"
+)
+
+// fakeDNSRecord is a single A, MX or TXT record served by startFakeDNS
+type fakeDNSRecord struct {
+	qtype dnsmessage.Type
+	ttl   uint32
+	value string
+}
+
+// startFakeDNS serves the given zone over UDP and returns the server address
+func startFakeDNS(t *testing.T, zone map[string][]fakeDNSRecord) string {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			var query dnsmessage.Message
+			if err := query.Unpack(buf[:n]); err != nil || len(query.Questions) != 1 {
+				continue
+			}
+			q := query.Questions[0]
+
+			header := dnsmessage.Header{ID: query.ID, Response: true, RCode: dnsmessage.RCodeSuccess}
+			records, ok := zone[strings.ToLower(q.Name.String())]
+			if !ok {
+				header.RCode = dnsmessage.RCodeNameError
+			}
+
+			b := dnsmessage.NewBuilder(nil, header)
+			_ = b.StartQuestions()
+			_ = b.Question(q)
+			_ = b.StartAnswers()
+			for _, r := range records {
+				if r.qtype != q.Type {
+					continue
+				}
+				rh := dnsmessage.ResourceHeader{Name: q.Name, Class: dnsmessage.ClassINET, TTL: r.ttl}
+				switch r.qtype {
+				case dnsmessage.TypeA:
+					var a [4]byte
+					copy(a[:], net.ParseIP(r.value).To4())
+					_ = b.AResource(rh, dnsmessage.AResource{A: a})
+				case dnsmessage.TypeMX:
+					parts := strings.Fields(r.value)
+					_ = b.MXResource(rh, dnsmessage.MXResource{Pref: 10, MX: dnsmessage.MustNewName(parts[1])})
+				case dnsmessage.TypeTXT:
+					_ = b.TXTResource(rh, dnsmessage.TXTResource{TXT: []string{r.value}})
+				}
+			}
+			msg, err := b.Finish()
+			if err != nil {
+				continue
+			}
+			_, _ = conn.WriteTo(msg, addr)
+		}
+	}()
+
+	return conn.LocalAddr().String()
+}
+
+func TestDNSDriver(t *testing.T) {
+	primary := startFakeDNS(t, map[string][]fakeDNSRecord{
+		"api.example.com.": {
+			{dnsmessage.TypeA, 300, "10.0.0.1"},
+			{dnsmessage.TypeA, 60, "10.0.0.2"},
+		},
+		"example.com.": {
+			{dnsmessage.TypeMX, 3600, "10 mx1.example.com."},
+			{dnsmessage.TypeTXT, 3600, "v=spf1 -all"},
+		},
+	})
+	stale := startFakeDNS(t, map[string][]fakeDNSRecord{
+		"api.example.com.": {{dnsmessage.TypeA, 300, "10.0.0.9"}},
+	})
+
+	minTTL, maxTTL := int32(120), int32(600)
+	tests := []struct {
+		name      string
+		endpoint  string
+		check     v1.DnsCheck
+		success   bool
+		contained string
+	}{
+		{
+			name:     "expected A answers in any order",
+			endpoint: "api.example.com",
+			check:    v1.DnsCheck{Nameservers: []string{primary}, ExpectedAnswers: []string{"10.0.0.2", "10.0.0.1"}},
+			success:  true,
+		},
+		{
+			name:      "unexpected A answers",
+			endpoint:  "api.example.com",
+			check:     v1.DnsCheck{Nameservers: []string{primary}, ExpectedAnswers: []string{"10.0.0.1"}},
+			contained: "expected [10.0.0.1]",
+		},
+		{
+			name:     "MX and TXT records",
+			endpoint: "example.com",
+			check:    v1.DnsCheck{Nameservers: []string{primary}, RecordType: "MX", ExpectedAnswers: []string{"10 MX1.example.com."}},
+			success:  true,
+		},
+		{
+			name:     "TXT pattern",
+			endpoint: "example.com",
+			check:    v1.DnsCheck{Nameservers: []string{primary}, RecordType: "TXT", AnswerPattern: `^v=spf1 `},
+			success:  true,
+		},
+		{
+			name:      "TTL bounds",
+			endpoint:  "api.example.com",
+			check:     v1.DnsCheck{Nameservers: []string{primary}, MinTTL: &minTTL, MaxTTL: &maxTTL},
+			contained: "TTL 60 is below 120",
+		},
+		{
+			name:     "expected NXDOMAIN",
+			endpoint: "gone.example.com",
+			check:    v1.DnsCheck{Nameservers: []string{primary}, ExpectedRcode: "NXDOMAIN"},
+			success:  true,
+		},
+		{
+			name:      "unexpected NXDOMAIN",
+			endpoint:  "gone.example.com",
+			check:     v1.DnsCheck{Nameservers: []string{primary}},
+			contained: "returned NXDOMAIN, expected NOERROR",
+		},
+		{
+			name:      "split brain between resolvers",
+			endpoint:  "api.example.com",
+			check:     v1.DnsCheck{Nameservers: []string{primary, stale}, CompareResolvers: true},
+			contained: "resolvers disagree",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := NewDNSDriver(tt.endpoint, &tt.check)
+			if err != nil {
+				t.Fatalf("unexpected error creating driver: %v", err)
+			}
+			result, err := d.Check()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Success != tt.success {
+				t.Fatalf("expected success=%t, got %t: %s", tt.success, result.Success, result.Message)
+			}
+			if tt.contained != "" && !strings.Contains(result.Message, tt.contained) {
+				t.Errorf("expected message to contain %q, got: %s", tt.contained, result.Message)
+			}
+		})
+	}
+}
+
+func TestDNSDriverSearchDomains(t *testing.T) {
+	server := startFakeDNS(t, map[string][]fakeDNSRecord{
+		"db.apps.svc.cluster.local.": {{dnsmessage.TypeA, 30, "10.96.0.15"}},
+	})
+	host, port, _ := net.SplitHostPort(server)
+
+	path := filepath.Join(t.TempDir(), "resolv.conf")
+	conf := "nameserver " + host + "\nsearch apps.svc.cluster.local svc.cluster.local\noptions ndots:5\n"
+	if err := os.WriteFile(path, []byte(conf), 0o600); err != nil {
+		t.Fatalf("failed to write resolv.conf: %v", err)
+	}
+	defer func(old string) { resolvConfPath = old }(resolvConfPath)
+	resolvConfPath = path
+
+	d, err := NewDNSDriver("db", &v1.DnsCheck{ExpectedAnswers: []string{"10.96.0.15"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	dnsDriver := d.(*DNSDriver)
+	servers, names, err := dnsDriver.resolverConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(names) != 3 || names[0] != "db.apps.svc.cluster.local." {
+		t.Fatalf("unexpected search names: %v", names)
+	}
+
+	// resolv.conf cannot carry a port, so query the fake server's random port directly
+	resp, err := dnsDriver.resolve(net.JoinHostPort(host, port), names)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(servers) != 1 || len(resp.answers) != 1 || resp.answers[0] != "10.96.0.15" {
+		t.Errorf("unexpected resolution: servers=%v answers=%v", servers, resp.answers)
+	}
+}
+
+func TestDNSDriverSystemResolver(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint string
+		check    *v1.DnsCheck
+		success  bool
+	}{
+		{name: "IPv6 literal", endpoint: "::1", success: true},
+		{name: "IPv4 literal with expected answer", endpoint: "127.0.0.1", check: &v1.DnsCheck{ExpectedAnswers: []string{"127.0.0.1"}}, success: true},
+		{name: "hosts entry", endpoint: "localhost", success: true},
+		{name: "unexpected answer", endpoint: "127.0.0.1", check: &v1.DnsCheck{ExpectedAnswers: []string{"10.0.0.1"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := NewDNSDriver(tt.endpoint, tt.check)
+			if err != nil {
+				t.Fatalf("unexpected error creating driver: %v", err)
+			}
+			if !d.(*DNSDriver).system {
+				t.Fatalf("expected the host resolver without a record type")
+			}
+			result, err := d.Check()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Success != tt.success {
+				t.Errorf("expected success=%t, got %t: %s", tt.success, result.Success, result.Message)
+			}
+		})
+	}
+}
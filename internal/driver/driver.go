@@ -1,13 +1,43 @@
 package driver
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
+
+// Check statuses reported to notifiers and recorded in the monitor status
+const (
+	StatusSuccess  = "success"
+	StatusDegraded = "degraded"
+	StatusFailure  = "failure"
+)
 
 // CheckResult represents the result of a health check
 type CheckResult struct {
 	Success      bool
+	Degraded     bool // target works but not within expected bounds; only meaningful when Success is true
 	ResponseTime time.Duration
 	Error        error
 	Message      string
+
+	// Response data exposed to success expressions
+	StatusCode int                    // HTTP status code, 0 for non-HTTP drivers
+	Headers    map[string]string      // HTTP response headers with lower-cased names
+	Body       interface{}            // decoded JSON body, or the raw body when it is not JSON
+	Details    map[string]interface{} // driver-specific fields, e.g. OpenSearch cluster health
+}
+
+// Credentials holds a username and password resolved from a Secret
+type Credentials struct {
+	Username string
+	Password string
+}
+
+// TLSMaterial holds PEM encoded TLS material resolved from a Secret
+type TLSMaterial struct {
+	CA   []byte
+	Cert []byte
+	Key  []byte
 }
 
 // Driver interface for different monitoring types
@@ -16,3 +46,16 @@ type Driver interface {
 	GetEndpoint() string
 	GetType() string
 }
+
+// toDetails converts a decoded response struct into a generic map using its JSON field names
+func toDetails(v interface{}) map[string]interface{} {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var details map[string]interface{}
+	if err := json.Unmarshal(data, &details); err != nil {
+		return nil
+	}
+	return details
+}
@@ -0,0 +1,144 @@
+package driver
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+)
+
+// maxBodySize limits how much of a response body is read
+const maxBodySize = 10 << 20
+
+// bodyCapturer is implemented by drivers that only keep response bodies once a success
+// expression asks for them, sparing every other check the decoding
+type bodyCapturer interface {
+	captureBodies()
+}
+
+// ExpressionDriver wraps another driver and decides success with a CEL expression
+type ExpressionDriver struct {
+	Driver
+	expression string
+	program    cel.Program
+}
+
+// NewExpressionDriver compiles expression and returns a driver that evaluates it
+// against every result produced by base. The expression must return a bool and
+// may reference: success, responseTime, statusCode, headers, body and details.
+func NewExpressionDriver(base Driver, expression string) (Driver, error) {
+	env, err := cel.NewEnv(
+		cel.Variable("success", cel.BoolType),
+		cel.Variable("responseTime", cel.DurationType),
+		cel.Variable("statusCode", cel.IntType),
+		cel.Variable("headers", cel.MapType(cel.StringType, cel.StringType)),
+		cel.Variable("body", cel.DynType),
+		cel.Variable("details", cel.MapType(cel.StringType, cel.DynType)),
+		cel.CrossTypeNumericComparisons(true),
+		ext.Strings(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CEL environment: %w", err)
+	}
+
+	ast, issues := env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, fmt.Errorf("invalid success expression: %w", issues.Err())
+	}
+	if ast.OutputType() != cel.BoolType && ast.OutputType() != cel.DynType {
+		return nil, fmt.Errorf("success expression must evaluate to bool, got %s", ast.OutputType())
+	}
+
+	program, err := env.Program(ast)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build success expression: %w", err)
+	}
+
+	if capturer, ok := base.(bodyCapturer); ok {
+		capturer.captureBodies()
+	}
+	return &ExpressionDriver{Driver: base, expression: expression, program: program}, nil
+}
+
+func (e *ExpressionDriver) Check() (*CheckResult, error) {
+	result, err := e.Driver.Check()
+	if err != nil || result == nil {
+		return result, err
+	}
+
+	// The target could not be reached; there is nothing to evaluate
+	if result.Error != nil {
+		return result, nil
+	}
+
+	headers := result.Headers
+	if headers == nil {
+		headers = map[string]string{}
+	}
+	details := result.Details
+	if details == nil {
+		details = map[string]interface{}{}
+	}
+
+	out, _, evalErr := e.program.Eval(map[string]interface{}{
+		"success":      result.Success,
+		"responseTime": result.ResponseTime,
+		"statusCode":   result.StatusCode,
+		"headers":      headers,
+		"body":         result.Body,
+		"details":      details,
+	})
+	if evalErr != nil {
+		result.Success = false
+		result.Error = evalErr
+		result.Message = fmt.Sprintf("success expression failed to evaluate: %v (%s)", evalErr, result.Message)
+		return result, nil
+	}
+
+	passed, ok := out.Value().(bool)
+	if !ok {
+		result.Success = false
+		result.Message = fmt.Sprintf("success expression returned %v instead of bool (%s)", out.Value(), result.Message)
+		return result, nil
+	}
+
+	result.Success = passed
+	result.Message = fmt.Sprintf("%s (successExpression %q: %t)", result.Message, e.expression, passed)
+	return result, nil
+}
+
+// captureResponse records status code and headers on result so they can be
+// referenced from success expressions
+func captureResponse(result *CheckResult, resp *http.Response) {
+	result.StatusCode = resp.StatusCode
+	result.Headers = make(map[string]string, len(resp.Header))
+	for name, values := range resp.Header {
+		result.Headers[strings.ToLower(name)] = strings.Join(values, ", ")
+	}
+}
+
+// captureBody records body on result for success expressions, decoded as JSON when possible
+func captureBody(result *CheckResult, body []byte) {
+	var decoded interface{}
+	if err := json.Unmarshal(body, &decoded); err == nil {
+		result.Body = decoded
+	} else {
+		result.Body = string(body)
+	}
+}
+
+// readBody reads a response body, failing rather than truncating it beyond maxBodySize
+func readBody(r io.Reader) ([]byte, error) {
+	body, err := io.ReadAll(io.LimitReader(r, maxBodySize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(body) > maxBodySize {
+		return nil, fmt.Errorf("response body exceeds %dMB", maxBodySize>>20)
+	}
+	return body, nil
+}
@@ -0,0 +1,90 @@
+package driver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	v1 "github.com/LiciousTech/endpoint-monitoring-operator/api/v1alpha1"
+)
+
+func TestExpressionDriver(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Cluster-Version", "4.2.1")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"readyReplicas": 3, "version": "4.2.1", "draining": true}`))
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		expression string
+		success    bool
+	}{
+		{`success`, false},
+		{`body.readyReplicas >= 2 && body.version.startsWith('4.')`, true},
+		{`statusCode == 503 && body.draining`, true},
+		{`headers['x-cluster-version'] == '4.2.1' && responseTime < duration('10s')`, true},
+		{`body.readyReplicas > 5`, false},
+		{`body.missing == 1`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			base, err := NewHTTPDriver(srv.URL)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			d, err := NewExpressionDriver(base, tt.expression)
+			if err != nil {
+				t.Fatalf("unexpected error compiling expression: %v", err)
+			}
+			result, err := d.Check()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Success != tt.success {
+				t.Errorf("expected success=%t, got %t: %s", tt.success, result.Success, result.Message)
+			}
+		})
+	}
+}
+
+func TestExpressionDriverInvalid(t *testing.T) {
+	base, _ := NewHTTPDriver("http://localhost")
+	for _, expression := range []string{`statusCode +`, `statusCode + 1`, `unknown == 1`} {
+		if _, err := NewExpressionDriver(base, expression); err == nil {
+			t.Errorf("expected error for expression %q", expression)
+		}
+	}
+}
+
+func TestResponseBodyCapture(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/large" {
+			_, _ = w.Write([]byte(`{"padding": "` + strings.Repeat("x", maxBodySize) + `"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status": "UP"}`))
+	}))
+	defer srv.Close()
+
+	d, _ := NewHTTPDriver(srv.URL)
+	result, err := d.Check()
+	if err != nil || !result.Success {
+		t.Fatalf("expected success, got %+v, %v", result, err)
+	}
+	if result.Body != nil {
+		t.Errorf("expected the body to be skipped without a success expression, got %v", result.Body)
+	}
+
+	d, _ = NewHTTPJSONDriver(srv.URL+"/large", &v1.HttpJsonCheck{})
+	result, err = d.Check()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Success || !strings.Contains(result.Message, "response body exceeds 10MB") {
+		t.Errorf("expected an oversized body to fail with its limit, got: %s", result.Message)
+	}
+}
@@ -2,13 +2,15 @@ package driver
 
 import (
 	"fmt"
+	"io"
 	"net/http"
 	"time"
 )
 
 type HTTPDriver struct {
-	endpoint string
-	client   *http.Client
+	endpoint    string
+	client      *http.Client
+	captureBody bool // set when a success expression reads the body
 }
 
 func NewHTTPDriver(endpoint string) (Driver, error) {
@@ -43,6 +45,21 @@ func (h *HTTPDriver) Check() (*CheckResult, error) {
 
 	defer resp.Body.Close()
 
+	captureResponse(result, resp)
+	if h.captureBody {
+		body, err := readBody(resp.Body)
+		if err != nil {
+			result.Success = false
+			result.Error = err
+			result.Message = fmt.Sprintf("HTTP check failed to read response body: %v", err)
+			return result, nil
+		}
+		captureBody(result, body)
+	} else {
+		// Drain the body so that the connection can be reused
+		_, _ = io.Copy(io.Discard, resp.Body)
+	}
+
 	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
 		result.Success = true
 		result.Message = fmt.Sprintf("HTTP check successful (status: %d, response time: %v)", resp.StatusCode, duration)
@@ -54,6 +71,10 @@ func (h *HTTPDriver) Check() (*CheckResult, error) {
 	return result, nil
 }
 
+func (h *HTTPDriver) captureBodies() {
+	h.captureBody = true
+}
+
 func (h *HTTPDriver) GetEndpoint() string {
 	return h.endpoint
 }
@@ -3,7 +3,6 @@ package driver
 import (
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"strings"
 	"time"
@@ -12,9 +11,10 @@ import (
 )
 
 type HTTPJSONDriver struct {
-	endpoint   string
-	client     *http.Client
-	assertions map[string]string
+	endpoint    string
+	client      *http.Client
+	assertions  []*jsonAssertion
+	captureBody bool // set when a success expression reads the body
 }
 
 func NewHTTPJSONDriver(endpoint string, check *v1.HttpJsonCheck) (Driver, error) {
@@ -22,9 +22,14 @@ func NewHTTPJSONDriver(endpoint string, check *v1.HttpJsonCheck) (Driver, error)
 		return nil, fmt.Errorf("invalid endpoint or config for http-json")
 	}
 
+	assertions, err := compileAssertions(check.JsonAssertions, check.Assertions)
+	if err != nil {
+		return nil, fmt.Errorf("invalid http-json assertions: %w", err)
+	}
+
 	return &HTTPJSONDriver{
 		endpoint:   endpoint,
-		assertions: check.JsonAssertions,
+		assertions: assertions,
 		client: &http.Client{
 			Timeout: 30 * time.Second,
 		},
@@ -46,37 +51,49 @@ func (h *HTTPJSONDriver) Check() (*CheckResult, error) {
 	}
 	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	captureResponse(result, resp)
+	body, err := readBody(resp.Body)
 	if err != nil {
 		result.Success = false
 		result.Error = err
-		result.Message = "failed to read HTTP response body"
+		result.Message = fmt.Sprintf("failed to read HTTP response body: %v", err)
 		return result, nil
 	}
 
-	var payload map[string]interface{}
+	var payload interface{}
 	if err := json.Unmarshal(body, &payload); err != nil {
 		result.Success = false
 		result.Error = err
 		result.Message = "invalid JSON response"
 		return result, nil
 	}
+	if h.captureBody {
+		result.Body = payload
+	}
 
-	// Simple assertion logic
-	for path, expected := range h.assertions {
-		actual, ok := resolveJSONPath(payload, path)
-		if !ok || fmt.Sprintf("%v", actual) != expected {
-			result.Success = false
-			result.Message = fmt.Sprintf("assertion failed at '%s': expected '%s', got '%v'", path, expected, actual)
-			return result, nil
+	// Evaluate every assertion so that all failures are reported at once
+	var failures []string
+	for _, assertion := range h.assertions {
+		if failure := assertion.evaluate(payload); failure != "" {
+			failures = append(failures, failure)
 		}
 	}
+	if len(failures) > 0 {
+		result.Success = false
+		result.Message = fmt.Sprintf("%d of %d assertions failed: %s",
+			len(failures), len(h.assertions), strings.Join(failures, "; "))
+		return result, nil
+	}
 
 	result.Success = true
 	result.Message = fmt.Sprintf("HTTP-JSON check successful (response time: %v)", duration)
 	return result, nil
 }
 
+func (h *HTTPJSONDriver) captureBodies() {
+	h.captureBody = true
+}
+
 func (h *HTTPJSONDriver) GetEndpoint() string {
 	return h.endpoint
 }
@@ -84,21 +101,3 @@ func (h *HTTPJSONDriver) GetEndpoint() string {
 func (h *HTTPJSONDriver) GetType() string {
 	return "http-json"
 }
-
-// resolveJSONPath traverses a map[string]interface{} based on dot-separated path (e.g., "cluster.status")
-func resolveJSONPath(data map[string]interface{}, path string) (interface{}, bool) {
-	keys := strings.Split(path, ".")
-	var current interface{} = data
-
-	for _, key := range keys {
-		m, ok := current.(map[string]interface{})
-		if !ok {
-			return nil, false
-		}
-		current, ok = m[key]
-		if !ok {
-			return nil, false
-		}
-	}
-	return current, true
-}
@@ -0,0 +1,134 @@
+package driver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	v1 "github.com/LiciousTech/endpoint-monitoring-operator/api/v1alpha1"
+)
+
+const clusterStatus = `{
+  "status": "UP",
+  "version": "4.2.1",
+  "replicas": 3,
+  "maintenance": false,
+  "cluster": {"status": "green"},
+  "nodes": [
+    {"name": "node-a", "status": "ready", "load": 0.4},
+    {"name": "node-b", "status": "ready", "load": 0.9}
+  ]
+}`
+
+func newJSONServer(t *testing.T, body string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestHTTPJSONDriverAssertions(t *testing.T) {
+	srv := newJSONServer(t, clusterStatus)
+
+	tests := []struct {
+		name      string
+		check     v1.HttpJsonCheck
+		success   bool
+		failures  int
+		contained string
+	}{
+		{
+			name:    "legacy dot paths",
+			check:   v1.HttpJsonCheck{JsonAssertions: map[string]string{"status": "UP", "cluster.status": "green", "replicas": "3"}},
+			success: true,
+		},
+		{
+			name: "array index and wildcard",
+			check: v1.HttpJsonCheck{Assertions: []v1.JsonAssertion{
+				{Path: "$.nodes[0].status", Value: "ready"},
+				{Path: "nodes[*].status", Operator: "in", Values: []string{"ready", "draining"}},
+				{Path: "nodes", Operator: "length", Value: "2"},
+			}},
+			success: true,
+		},
+		{
+			name: "filter expression",
+			check: v1.HttpJsonCheck{Assertions: []v1.JsonAssertion{
+				{Path: "{.nodes[?(@.name=='node-b')].load}", Operator: "lt", Value: "1"},
+			}},
+			success: true,
+		},
+		{
+			name: "typed comparisons",
+			check: v1.HttpJsonCheck{Assertions: []v1.JsonAssertion{
+				{Path: "replicas", Operator: "gte", Value: "2"},
+				{Path: "maintenance", Value: "false"},
+				{Path: "version", Operator: "regex", Value: `^4\.`},
+				{Path: "version", Operator: "ne", Value: "3.0.0"},
+				{Path: "cluster.region", Operator: "exists", Value: "false"},
+			}},
+			success: true,
+		},
+		{
+			name: "numeric typing avoids string ordering",
+			check: v1.HttpJsonCheck{Assertions: []v1.JsonAssertion{
+				{Path: "replicas", Operator: "gt", Value: "10", Type: "number"},
+			}},
+			failures:  1,
+			contained: "expected gt '10', got '3'",
+		},
+		{
+			name: "all failures reported",
+			check: v1.HttpJsonCheck{
+				JsonAssertions: map[string]string{"status": "DOWN"},
+				Assertions: []v1.JsonAssertion{
+					{Path: "nodes[*].load", Operator: "lt", Value: "0.5"},
+					{Path: "missing.field", Operator: "exists"},
+				},
+			},
+			failures:  3,
+			contained: "assertion failed at 'missing.field'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := NewHTTPJSONDriver(srv.URL, &tt.check)
+			if err != nil {
+				t.Fatalf("unexpected error creating driver: %v", err)
+			}
+			result, err := d.Check()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Success != tt.success {
+				t.Fatalf("expected success=%t, got %t: %s", tt.success, result.Success, result.Message)
+			}
+			if tt.failures > 0 && strings.Count(result.Message, "assertion failed") != tt.failures {
+				t.Errorf("expected %d failures, got message: %s", tt.failures, result.Message)
+			}
+			if tt.contained != "" && !strings.Contains(result.Message, tt.contained) {
+				t.Errorf("expected message to contain %q, got: %s", tt.contained, result.Message)
+			}
+		})
+	}
+}
+
+func TestHTTPJSONDriverInvalidAssertions(t *testing.T) {
+	invalid := []v1.JsonAssertion{
+		{Path: "status", Operator: "contains", Value: "UP"},
+		{Path: "status", Operator: "regex", Value: "("},
+		{Path: "status", Operator: "in"},
+		{Path: "status", Type: "date"},
+		{Path: "nodes[", Value: "x"},
+	}
+	for _, a := range invalid {
+		if _, err := NewHTTPJSONDriver("http://localhost", &v1.HttpJsonCheck{Assertions: []v1.JsonAssertion{a}}); err == nil {
+			t.Errorf("expected error for assertion %+v", a)
+		}
+	}
+}
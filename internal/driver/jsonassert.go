@@ -0,0 +1,338 @@
+package driver
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/client-go/util/jsonpath"
+
+	v1 "github.com/LiciousTech/endpoint-monitoring-operator/api/v1alpha1"
+)
+
+// Supported assertion operators
+const (
+	OperatorEq     = "eq"
+	OperatorNe     = "ne"
+	OperatorGt     = "gt"
+	OperatorLt     = "lt"
+	OperatorGte    = "gte"
+	OperatorLte    = "lte"
+	OperatorIn     = "in"
+	OperatorRegex  = "regex"
+	OperatorExists = "exists"
+	OperatorLength = "length"
+)
+
+// Supported assertion value types
+const (
+	TypeString  = "string"
+	TypeNumber  = "number"
+	TypeBoolean = "boolean"
+)
+
+// jsonAssertion is a validated, ready-to-evaluate form of v1.JsonAssertion
+type jsonAssertion struct {
+	path     string
+	operator string
+	value    string
+	values   []string
+	typ      string
+	parser   *jsonpath.JSONPath
+	pattern  *regexp.Regexp
+}
+
+// compileAssertions validates the legacy assertion map and the typed assertion list.
+// Legacy entries are converted to "eq" assertions and ordered by path so that
+// failure messages are deterministic.
+func compileAssertions(legacy map[string]string, typed []v1.JsonAssertion) ([]*jsonAssertion, error) {
+	specs := make([]v1.JsonAssertion, 0, len(legacy)+len(typed))
+
+	paths := make([]string, 0, len(legacy))
+	for path := range legacy {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		specs = append(specs, v1.JsonAssertion{Path: path, Operator: OperatorEq, Value: legacy[path]})
+	}
+	specs = append(specs, typed...)
+
+	compiled := make([]*jsonAssertion, 0, len(specs))
+	for _, spec := range specs {
+		a, err := compileAssertion(spec)
+		if err != nil {
+			return nil, err
+		}
+		compiled = append(compiled, a)
+	}
+	return compiled, nil
+}
+
+func compileAssertion(spec v1.JsonAssertion) (*jsonAssertion, error) {
+	if spec.Path == "" {
+		return nil, fmt.Errorf("assertion path cannot be empty")
+	}
+
+	a := &jsonAssertion{
+		path:     spec.Path,
+		operator: strings.ToLower(spec.Operator),
+		value:    spec.Value,
+		values:   spec.Values,
+		typ:      strings.ToLower(spec.Type),
+	}
+	if a.operator == "" {
+		a.operator = OperatorEq
+	}
+
+	switch a.operator {
+	case OperatorEq, OperatorNe, OperatorGt, OperatorLt, OperatorGte, OperatorLte, OperatorExists, OperatorLength:
+	case OperatorIn:
+		if len(a.values) == 0 {
+			return nil, fmt.Errorf("assertion at '%s': operator 'in' requires values", spec.Path)
+		}
+	case OperatorRegex:
+		pattern, err := regexp.Compile(a.value)
+		if err != nil {
+			return nil, fmt.Errorf("assertion at '%s': invalid regex: %w", spec.Path, err)
+		}
+		a.pattern = pattern
+	default:
+		return nil, fmt.Errorf("assertion at '%s': unsupported operator '%s'", spec.Path, spec.Operator)
+	}
+
+	switch a.typ {
+	case "", TypeString, TypeNumber, TypeBoolean:
+	default:
+		return nil, fmt.Errorf("assertion at '%s': unsupported type '%s'", spec.Path, spec.Type)
+	}
+
+	a.parser = jsonpath.New(spec.Path).AllowMissingKeys(true)
+	if err := a.parser.Parse(normalizeJSONPath(spec.Path)); err != nil {
+		return nil, fmt.Errorf("assertion at '%s': invalid JSONPath: %w", spec.Path, err)
+	}
+	return a, nil
+}
+
+// normalizeJSONPath accepts "a.b", "$.a.b", ".a.b" and "{.a.b}" and returns the
+// template form understood by k8s.io/client-go/util/jsonpath.
+func normalizeJSONPath(path string) string {
+	path = strings.TrimSpace(path)
+	if strings.HasPrefix(path, "{") {
+		return path
+	}
+	path = strings.TrimPrefix(path, "$")
+	if !strings.HasPrefix(path, ".") && !strings.HasPrefix(path, "[") {
+		path = "." + path
+	}
+	return "{" + path + "}"
+}
+
+// evaluate checks the assertion against the decoded JSON document and returns
+// a human readable failure, or an empty string when the assertion holds.
+func (a *jsonAssertion) evaluate(doc interface{}) string {
+	matches, err := a.find(doc)
+	if err != nil {
+		return fmt.Sprintf("assertion failed at '%s': %v", a.path, err)
+	}
+
+	if a.operator == OperatorExists {
+		want := a.value == "" || strings.EqualFold(a.value, "true")
+		if (len(matches) > 0) != want {
+			return fmt.Sprintf("assertion failed at '%s': expected exists=%t, got %d match(es)", a.path, want, len(matches))
+		}
+		return ""
+	}
+
+	if len(matches) == 0 {
+		return fmt.Sprintf("assertion failed at '%s': path not found", a.path)
+	}
+
+	if a.operator == OperatorLength {
+		length := len(matches)
+		if len(matches) == 1 {
+			if l, ok := lengthOf(matches[0]); ok {
+				length = l
+			}
+		}
+		if err := a.compare(float64(length), OperatorEq, a.value); err != nil {
+			return fmt.Sprintf("assertion failed at '%s': expected length %s, got %d", a.path, a.value, length)
+		}
+		return ""
+	}
+
+	// Every value selected by the path must satisfy the assertion
+	for _, actual := range matches {
+		if err := a.check(actual); err != nil {
+			return fmt.Sprintf("assertion failed at '%s': %v", a.path, err)
+		}
+	}
+	return ""
+}
+
+func (a *jsonAssertion) find(doc interface{}) ([]interface{}, error) {
+	results, err := a.parser.FindResults(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []interface{}
+	for _, set := range results {
+		for _, v := range set {
+			if !v.IsValid() {
+				continue
+			}
+			if v.Kind() == reflect.Interface && v.IsNil() {
+				matches = append(matches, nil)
+				continue
+			}
+			matches = append(matches, v.Interface())
+		}
+	}
+	return matches, nil
+}
+
+func (a *jsonAssertion) check(actual interface{}) error {
+	switch a.operator {
+	case OperatorRegex:
+		s := stringify(actual)
+		if !a.pattern.MatchString(s) {
+			return fmt.Errorf("expected match for /%s/, got '%s'", a.value, s)
+		}
+		return nil
+	case OperatorIn:
+		for _, candidate := range a.values {
+			if a.compare(actual, OperatorEq, candidate) == nil {
+				return nil
+			}
+		}
+		return fmt.Errorf("expected one of %v, got '%s'", a.values, stringify(actual))
+	default:
+		return a.compare(actual, a.operator, a.value)
+	}
+}
+
+// compare applies op between actual and expected using the assertion type,
+// or a type inferred from the JSON value when none is configured.
+func (a *jsonAssertion) compare(actual interface{}, op string, expected string) error {
+	typ := a.typ
+	if typ == "" {
+		switch actual.(type) {
+		case float64:
+			typ = TypeNumber
+		case bool:
+			typ = TypeBoolean
+		default:
+			typ = TypeString
+		}
+	}
+
+	var cmp int
+	switch typ {
+	case TypeNumber:
+		got, err := toFloat(actual)
+		if err != nil {
+			return err
+		}
+		want, err := strconv.ParseFloat(strings.TrimSpace(expected), 64)
+		if err != nil {
+			return fmt.Errorf("expected value '%s' is not a number", expected)
+		}
+		switch {
+		case got < want:
+			cmp = -1
+		case got > want:
+			cmp = 1
+		}
+	case TypeBoolean:
+		got, err := toBool(actual)
+		if err != nil {
+			return err
+		}
+		want, err := strconv.ParseBool(strings.TrimSpace(expected))
+		if err != nil {
+			return fmt.Errorf("expected value '%s' is not a boolean", expected)
+		}
+		if op != OperatorEq && op != OperatorNe {
+			return fmt.Errorf("operator '%s' is not supported for booleans", op)
+		}
+		if got != want {
+			cmp = 1
+		}
+	default:
+		cmp = strings.Compare(stringify(actual), expected)
+	}
+
+	var ok bool
+	switch op {
+	case OperatorEq:
+		ok = cmp == 0
+	case OperatorNe:
+		ok = cmp != 0
+	case OperatorGt:
+		ok = cmp > 0
+	case OperatorLt:
+		ok = cmp < 0
+	case OperatorGte:
+		ok = cmp >= 0
+	case OperatorLte:
+		ok = cmp <= 0
+	}
+	if !ok {
+		return fmt.Errorf("expected %s '%s', got '%s'", op, expected, stringify(actual))
+	}
+	return nil
+}
+
+func lengthOf(v interface{}) (int, bool) {
+	switch t := v.(type) {
+	case []interface{}:
+		return len(t), true
+	case map[string]interface{}:
+		return len(t), true
+	case string:
+		return len(t), true
+	}
+	return 0, false
+}
+
+func toFloat(v interface{}) (float64, error) {
+	switch t := v.(type) {
+	case float64:
+		return t, nil
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(t), 64)
+		if err != nil {
+			return 0, fmt.Errorf("value '%s' is not a number", t)
+		}
+		return f, nil
+	}
+	return 0, fmt.Errorf("value '%s' is not a number", stringify(v))
+}
+
+func toBool(v interface{}) (bool, error) {
+	switch t := v.(type) {
+	case bool:
+		return t, nil
+	case string:
+		b, err := strconv.ParseBool(strings.TrimSpace(t))
+		if err != nil {
+			return false, fmt.Errorf("value '%s' is not a boolean", t)
+		}
+		return b, nil
+	}
+	return false, fmt.Errorf("value '%s' is not a boolean", stringify(v))
+}
+
+func stringify(v interface{}) string {
+	if v == nil {
+		return "null"
+	}
+	if f, ok := v.(float64); ok {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	return fmt.Sprintf("%v", v)
+}
@@ -0,0 +1,293 @@
+package driver
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/sasl"
+	"github.com/twmb/franz-go/pkg/sasl/plain"
+	"github.com/twmb/franz-go/pkg/sasl/scram"
+
+	v1 "github.com/LiciousTech/endpoint-monitoring-operator/api/v1alpha1"
+)
+
+const defaultKafkaTimeout = 10 * time.Second
+
+type KafkaDriver struct {
+	endpoint       string
+	topics         []string
+	minBrokers     int
+	consumerGroups []v1.KafkaConsumerGroup
+	timeout        time.Duration
+	opts           []kgo.Opt
+}
+
+func NewKafkaDriver(endpoint string, check *v1.KafkaCheck, creds *Credentials, tlsMaterial *TLSMaterial) (Driver, error) {
+	if endpoint == "" {
+		return nil, fmt.Errorf("endpoint cannot be empty")
+	}
+	if check == nil {
+		check = &v1.KafkaCheck{}
+	}
+
+	k := &KafkaDriver{
+		endpoint:       endpoint,
+		topics:         check.Topics,
+		consumerGroups: check.ConsumerGroups,
+		timeout:        defaultKafkaTimeout,
+	}
+	var seeds []string
+	for _, seed := range strings.Split(endpoint, ",") {
+		if seed = strings.TrimSpace(seed); seed != "" {
+			seeds = append(seeds, seed)
+		}
+	}
+	if check.MinBrokers != nil {
+		k.minBrokers = int(*check.MinBrokers)
+	}
+	if check.Timeout != nil && check.Timeout.Duration > 0 {
+		k.timeout = check.Timeout.Duration
+	}
+	for _, group := range check.ConsumerGroups {
+		if group.Name == "" {
+			return nil, fmt.Errorf("consumer group name cannot be empty")
+		}
+	}
+
+	k.opts = []kgo.Opt{
+		kgo.SeedBrokers(seeds...),
+		kgo.DialTimeout(k.timeout),
+		kgo.RequestRetries(1),
+	}
+
+	if check.SASL != nil {
+		mechanism, err := saslMechanism(check.SASL.Mechanism, creds)
+		if err != nil {
+			return nil, err
+		}
+		k.opts = append(k.opts, kgo.SASL(mechanism))
+	}
+
+	tlsEnabled := (check.TLS != nil && check.TLS.Enabled) || tlsMaterial != nil
+	if tlsEnabled {
+		tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+		if check.TLS != nil {
+			// An empty ServerName lets the client verify each broker against its own host name
+			tlsConfig.ServerName = check.TLS.ServerName
+			tlsConfig.InsecureSkipVerify = check.TLS.InsecureSkipVerify //nolint:gosec // explicitly requested per monitor
+		}
+		if tlsMaterial != nil {
+			if len(tlsMaterial.CA) > 0 {
+				pool := x509.NewCertPool()
+				if !pool.AppendCertsFromPEM(tlsMaterial.CA) {
+					return nil, fmt.Errorf("no valid certificates in CA bundle")
+				}
+				tlsConfig.RootCAs = pool
+			}
+			if len(tlsMaterial.Cert) > 0 {
+				cert, err := tls.X509KeyPair(tlsMaterial.Cert, tlsMaterial.Key)
+				if err != nil {
+					return nil, fmt.Errorf("invalid client certificate: %w", err)
+				}
+				tlsConfig.Certificates = []tls.Certificate{cert}
+			}
+		}
+		k.opts = append(k.opts, kgo.DialTLSConfig(tlsConfig))
+	}
+
+	return k, nil
+}
+
+func saslMechanism(name string, creds *Credentials) (sasl.Mechanism, error) {
+	if creds == nil {
+		return nil, fmt.Errorf("SASL requires credentials")
+	}
+	switch strings.ToUpper(name) {
+	case "PLAIN":
+		return plain.Auth{User: creds.Username, Pass: creds.Password}.AsMechanism(), nil
+	case "SCRAM-SHA-256":
+		return scram.Auth{User: creds.Username, Pass: creds.Password}.AsSha256Mechanism(), nil
+	case "SCRAM-SHA-512":
+		return scram.Auth{User: creds.Username, Pass: creds.Password}.AsSha512Mechanism(), nil
+	default:
+		return nil, fmt.Errorf("unsupported SASL mechanism %q, expected PLAIN, SCRAM-SHA-256 or SCRAM-SHA-512", name)
+	}
+}
+
+func (k *KafkaDriver) Check() (*CheckResult, error) {
+	start := time.Now()
+	result := &CheckResult{}
+
+	client, err := kgo.NewClient(k.opts...)
+	if err != nil {
+		result.ResponseTime = time.Since(start)
+		result.Success = false
+		result.Error = err
+		result.Message = fmt.Sprintf("Kafka check failed: %v", err)
+		return result, nil
+	}
+	defer client.Close()
+	admin := kadm.NewClient(client)
+
+	ctx, cancel := context.WithTimeout(context.Background(), k.timeout)
+	defer cancel()
+
+	metadata, err := admin.Metadata(ctx, k.topics...)
+	if err != nil {
+		result.ResponseTime = time.Since(start)
+		result.Success = false
+		result.Error = err
+		result.Message = fmt.Sprintf("Kafka check failed to fetch metadata: %v", err)
+		return result, nil
+	}
+
+	var lags kadm.DescribedGroupLags
+	if len(k.consumerGroups) > 0 {
+		groups := make([]string, 0, len(k.consumerGroups))
+		for _, group := range k.consumerGroups {
+			groups = append(groups, group.Name)
+		}
+		if lags, err = admin.Lag(ctx, groups...); err != nil {
+			result.ResponseTime = time.Since(start)
+			result.Success = false
+			result.Error = err
+			result.Message = fmt.Sprintf("Kafka check failed to fetch consumer group lag: %v", err)
+			return result, nil
+		}
+	}
+
+	result.ResponseTime = time.Since(start)
+	k.evaluate(result, metadata, lags)
+	return result, nil
+}
+
+// evaluate fills result from the cluster metadata and consumer group lag. Offline
+// partitions fail the check while under-replicated ones only degrade it.
+func (k *KafkaDriver) evaluate(result *CheckResult, metadata kadm.Metadata, lags kadm.DescribedGroupLags) {
+	var failures, warnings []string
+
+	if len(metadata.Brokers) < k.minBrokers {
+		failures = append(failures, fmt.Sprintf("%d broker(s) available, expected at least %d", len(metadata.Brokers), k.minBrokers))
+	}
+	if metadata.Controller < 0 {
+		failures = append(failures, "no active controller")
+	}
+
+	var partitions int
+	var underReplicated, offline []string
+	for _, name := range metadata.Topics.Names() {
+		topic := metadata.Topics[name]
+		if topic.Err != nil {
+			failures = append(failures, fmt.Sprintf("topic %s: %v", name, topic.Err))
+			continue
+		}
+		for _, p := range topic.Partitions.Sorted() {
+			partitions++
+			id := fmt.Sprintf("%s-%d", name, p.Partition)
+			switch {
+			case p.Leader < 0:
+				offline = append(offline, id)
+			case len(p.ISR) < len(p.Replicas):
+				underReplicated = append(underReplicated, id)
+			}
+		}
+	}
+	if len(offline) > 0 {
+		failures = append(failures, fmt.Sprintf("%d offline partition(s): %s", len(offline), listPreview(offline)))
+	}
+	if len(underReplicated) > 0 {
+		warnings = append(warnings, fmt.Sprintf("%d under-replicated partition(s): %s", len(underReplicated), listPreview(underReplicated)))
+	}
+
+	groupLag := make(map[string]int64, len(k.consumerGroups))
+	for _, group := range k.consumerGroups {
+		described, ok := lags[group.Name]
+		if !ok {
+			failures = append(failures, fmt.Sprintf("consumer group %s: not described", group.Name))
+			continue
+		}
+		if err := described.Error(); err != nil {
+			failures = append(failures, fmt.Sprintf("consumer group %s: %v", group.Name, err))
+			continue
+		}
+		if described.State == "Dead" && len(described.Lag) == 0 {
+			failures = append(failures, fmt.Sprintf("consumer group %s does not exist", group.Name))
+			continue
+		}
+
+		var total int64
+		for _, l := range described.Lag.Sorted() {
+			if len(group.Topics) > 0 && !slices.Contains(group.Topics, l.Topic) {
+				continue
+			}
+			if l.Err != nil {
+				failures = append(failures, fmt.Sprintf("consumer group %s: %s-%d: %v", group.Name, l.Topic, l.Partition, l.Err))
+				continue
+			}
+			total += l.Lag
+		}
+		groupLag[group.Name] = total
+		if total > group.MaxLag {
+			failures = append(failures, fmt.Sprintf("consumer group %s lag %d exceeds %d", group.Name, total, group.MaxLag))
+		}
+	}
+
+	result.Details = map[string]interface{}{
+		"brokers":                   len(metadata.Brokers),
+		"controller":                metadata.Controller,
+		"partitions":                partitions,
+		"offlinePartitions":         len(offline),
+		"underReplicatedPartitions": len(underReplicated),
+		"consumerGroupLag":          groupLag,
+	}
+
+	summary := fmt.Sprintf("brokers: %d, partitions: %d, under-replicated: %d, offline: %d",
+		len(metadata.Brokers), partitions, len(underReplicated), len(offline))
+	groups := make([]string, 0, len(groupLag))
+	for name := range groupLag {
+		groups = append(groups, name)
+	}
+	sort.Strings(groups)
+	for _, name := range groups {
+		summary += fmt.Sprintf(", %s lag: %d", name, groupLag[name])
+	}
+	summary += fmt.Sprintf(", response time: %v", result.ResponseTime)
+
+	switch {
+	case len(failures) > 0:
+		result.Success = false
+		result.Message = fmt.Sprintf("Kafka check failed, %s (%s)", strings.Join(append(failures, warnings...), "; "), summary)
+	case len(warnings) > 0:
+		result.Success = true
+		result.Degraded = true
+		result.Message = fmt.Sprintf("Kafka cluster degraded, %s (%s)", strings.Join(warnings, "; "), summary)
+	default:
+		result.Success = true
+		result.Message = fmt.Sprintf("Kafka check successful (%s)", summary)
+	}
+}
+
+// listPreview joins at most a handful of items for status messages
+func listPreview(items []string) string {
+	const limit = 5
+	if len(items) > limit {
+		return strings.Join(items[:limit], ", ") + fmt.Sprintf(" and %d more", len(items)-limit)
+	}
+	return strings.Join(items, ", ")
+}
+
+func (k *KafkaDriver) GetEndpoint() string {
+	return k.endpoint
+}
+
+func (k *KafkaDriver) GetType() string {
+	return "kafka"
+}
@@ -0,0 +1,153 @@
+package driver
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/twmb/franz-go/pkg/kadm"
+
+	v1 "github.com/LiciousTech/endpoint-monitoring-operator/api/v1alpha1"
+)
+
+func kafkaMetadata(partitions ...kadm.PartitionDetail) kadm.Metadata {
+	topics := kadm.TopicDetails{}
+	for _, p := range partitions {
+		topic := topics[p.Topic]
+		topic.Topic = p.Topic
+		if topic.Partitions == nil {
+			topic.Partitions = kadm.PartitionDetails{}
+		}
+		topic.Partitions[p.Partition] = p
+		topics[p.Topic] = topic
+	}
+	return kadm.Metadata{
+		Controller: 1,
+		Brokers:    kadm.BrokerDetails{{NodeID: 1}, {NodeID: 2}, {NodeID: 3}},
+		Topics:     topics,
+	}
+}
+
+func healthyPartition(topic string, partition int32) kadm.PartitionDetail {
+	return kadm.PartitionDetail{Topic: topic, Partition: partition, Leader: 1, Replicas: []int32{1, 2, 3}, ISR: []int32{1, 2, 3}}
+}
+
+func groupLag(name string, lags ...kadm.GroupMemberLag) kadm.DescribedGroupLags {
+	lag := kadm.GroupLag{}
+	for _, l := range lags {
+		if lag[l.Topic] == nil {
+			lag[l.Topic] = map[int32]kadm.GroupMemberLag{}
+		}
+		lag[l.Topic][l.Partition] = l
+	}
+	return kadm.DescribedGroupLags{name: {Group: name, State: "Stable", Lag: lag}}
+}
+
+func TestKafkaDriverEvaluate(t *testing.T) {
+	minBrokers := int32(4)
+	underReplicated := healthyPartition("orders", 1)
+	underReplicated.ISR = []int32{1}
+	offline := healthyPartition("payments", 0)
+	offline.Leader = -1
+
+	tests := []struct {
+		name      string
+		check     *v1.KafkaCheck
+		metadata  kadm.Metadata
+		lags      kadm.DescribedGroupLags
+		success   bool
+		degraded  bool
+		contained string
+	}{
+		{
+			name:      "healthy cluster",
+			metadata:  kafkaMetadata(healthyPartition("orders", 0), healthyPartition("orders", 1)),
+			success:   true,
+			contained: "brokers: 3, partitions: 2, under-replicated: 0, offline: 0",
+		},
+		{
+			name:      "too few brokers",
+			check:     &v1.KafkaCheck{MinBrokers: &minBrokers},
+			metadata:  kafkaMetadata(healthyPartition("orders", 0)),
+			contained: "3 broker(s) available, expected at least 4",
+		},
+		{
+			name:      "under-replicated partitions degrade",
+			metadata:  kafkaMetadata(healthyPartition("orders", 0), underReplicated),
+			success:   true,
+			degraded:  true,
+			contained: "1 under-replicated partition(s): orders-1",
+		},
+		{
+			name:      "offline partitions fail",
+			metadata:  kafkaMetadata(healthyPartition("orders", 0), underReplicated, offline),
+			contained: "1 offline partition(s): payments-0; 1 under-replicated partition(s): orders-1",
+		},
+		{
+			name: "missing topic",
+			metadata: kadm.Metadata{Controller: 1, Topics: kadm.TopicDetails{
+				"audit": {Topic: "audit", Err: errors.New("UNKNOWN_TOPIC_OR_PARTITION")},
+			}},
+			contained: "topic audit: UNKNOWN_TOPIC_OR_PARTITION",
+		},
+		{
+			name:     "consumer lag within threshold",
+			check:    &v1.KafkaCheck{ConsumerGroups: []v1.KafkaConsumerGroup{{Name: "billing", MaxLag: 100}}},
+			metadata: kafkaMetadata(healthyPartition("orders", 0)),
+			lags: groupLag("billing",
+				kadm.GroupMemberLag{Topic: "orders", Partition: 0, Lag: 40},
+				kadm.GroupMemberLag{Topic: "orders", Partition: 1, Lag: 50}),
+			success:   true,
+			contained: "billing lag: 90",
+		},
+		{
+			name: "consumer lag over threshold on selected topics",
+			check: &v1.KafkaCheck{ConsumerGroups: []v1.KafkaConsumerGroup{
+				{Name: "billing", MaxLag: 100, Topics: []string{"orders"}},
+			}},
+			metadata: kafkaMetadata(healthyPartition("orders", 0)),
+			lags: groupLag("billing",
+				kadm.GroupMemberLag{Topic: "orders", Partition: 0, Lag: 150},
+				kadm.GroupMemberLag{Topic: "refunds", Partition: 0, Lag: 1000}),
+			contained: "consumer group billing lag 150 exceeds 100",
+		},
+		{
+			name:      "unknown consumer group",
+			check:     &v1.KafkaCheck{ConsumerGroups: []v1.KafkaConsumerGroup{{Name: "ghost", MaxLag: 10}}},
+			metadata:  kafkaMetadata(healthyPartition("orders", 0)),
+			lags:      kadm.DescribedGroupLags{"ghost": {Group: "ghost", State: "Dead"}},
+			contained: "consumer group ghost does not exist",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := NewKafkaDriver("broker-1:9092,broker-2:9092", tt.check, nil, nil)
+			if err != nil {
+				t.Fatalf("unexpected error creating driver: %v", err)
+			}
+			result := &CheckResult{}
+			d.(*KafkaDriver).evaluate(result, tt.metadata, tt.lags)
+			if result.Success != tt.success || result.Degraded != tt.degraded {
+				t.Fatalf("expected success=%t degraded=%t, got %t/%t: %s",
+					tt.success, tt.degraded, result.Success, result.Degraded, result.Message)
+			}
+			if tt.contained != "" && !strings.Contains(result.Message, tt.contained) {
+				t.Errorf("expected message to contain %q, got: %s", tt.contained, result.Message)
+			}
+		})
+	}
+}
+
+func TestKafkaDriverConfig(t *testing.T) {
+	if _, err := NewKafkaDriver("broker:9092", &v1.KafkaCheck{SASL: &v1.KafkaSASL{Mechanism: "SCRAM-SHA-512"}}, nil, nil); err == nil {
+		t.Error("expected SASL without credentials to be rejected")
+	}
+	if _, err := NewKafkaDriver("broker:9092", &v1.KafkaCheck{SASL: &v1.KafkaSASL{Mechanism: "GSSAPI"}},
+		&Credentials{Username: "u", Password: "p"}, nil); err == nil {
+		t.Error("expected unsupported SASL mechanism to be rejected")
+	}
+	if _, err := NewKafkaDriver("broker:9092", nil, nil, &TLSMaterial{CA: []byte("not a certificate")}); err == nil {
+		t.Error("expected invalid CA bundle to be rejected")
+	}
+}
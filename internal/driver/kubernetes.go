@@ -0,0 +1,341 @@
+package driver
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/LiciousTech/endpoint-monitoring-operator/api/v1alpha1"
+)
+
+const defaultKubernetesTimeout = 10 * time.Second
+
+// Kubernetes object kinds supported as targets
+const (
+	KindService     = "service"
+	KindDeployment  = "deployment"
+	KindStatefulSet = "statefulset"
+	KindPod         = "pod"
+)
+
+// KubernetesDriver checks the readiness of in-cluster workloads through the manager's client
+type KubernetesDriver struct {
+	endpoint    string
+	client      client.Reader
+	kind        string
+	name        string
+	namespace   string
+	selector    labels.Selector
+	minReady    int
+	maxRestarts *int32
+}
+
+// workloadState is what a target kind reports before the shared pod checks run
+type workloadState struct {
+	summary  string
+	failure  string
+	degraded string
+	selector labels.Selector
+}
+
+// NewKubernetesDriver checks a target in the monitor's namespace. check.Namespace may only
+// name another namespace when allowCrossNamespace is set, since the operator can read every
+// namespace and the monitor's author might not.
+func NewKubernetesDriver(c client.Reader, endpoint, namespace string, check *v1.KubernetesCheck, allowCrossNamespace bool) (Driver, error) {
+	if endpoint == "" {
+		return nil, fmt.Errorf("endpoint cannot be empty")
+	}
+	if c == nil {
+		return nil, fmt.Errorf("kubernetes driver requires a client")
+	}
+	if check == nil {
+		check = &v1.KubernetesCheck{}
+	}
+
+	k := &KubernetesDriver{
+		endpoint:    endpoint,
+		client:      c,
+		namespace:   namespace,
+		minReady:    1,
+		maxRestarts: check.MaxRestarts,
+	}
+	if check.Namespace != "" && check.Namespace != namespace {
+		if !allowCrossNamespace {
+			return nil, fmt.Errorf("kubernetesCheck.namespace %q differs from the monitor namespace %q; "+
+				"cross-namespace targets require the operator flag --allow-cross-namespace-targets", check.Namespace, namespace)
+		}
+		k.namespace = check.Namespace
+	}
+	if check.MinReady != nil {
+		k.minReady = int(*check.MinReady)
+	}
+
+	kind, name, _ := strings.Cut(endpoint, "/")
+	k.kind = strings.ToLower(strings.TrimSuffix(kind, "s"))
+	k.name = name
+	switch k.kind {
+	case KindService, KindDeployment, KindStatefulSet:
+		if k.name == "" {
+			return nil, fmt.Errorf("endpoint must be <kind>/<name>, got %q", endpoint)
+		}
+	case KindPod:
+		if check.Selector == nil {
+			return nil, fmt.Errorf("kubernetesCheck.selector is required for pods")
+		}
+		selector, err := metav1.LabelSelectorAsSelector(check.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid selector: %w", err)
+		}
+		k.selector = selector
+	default:
+		return nil, fmt.Errorf("unsupported kind %q, expected service, deployment, statefulset or pod", kind)
+	}
+
+	return k, nil
+}
+
+func (k *KubernetesDriver) Check() (*CheckResult, error) {
+	start := time.Now()
+	result := &CheckResult{}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultKubernetesTimeout)
+	defer cancel()
+
+	var state *workloadState
+	var err error
+	switch k.kind {
+	case KindService:
+		state, err = k.checkService(ctx)
+	case KindDeployment:
+		state, err = k.checkDeployment(ctx)
+	case KindStatefulSet:
+		state, err = k.checkStatefulSet(ctx)
+	case KindPod:
+		state = &workloadState{selector: k.selector}
+	}
+	if err != nil {
+		result.ResponseTime = time.Since(start)
+		result.Success = false
+		result.Error = err
+		result.Message = fmt.Sprintf("Kubernetes check failed for %s: %v", k.target(), err)
+		return result, nil
+	}
+
+	var pods corev1.PodList
+	if state.selector != nil && !state.selector.Empty() {
+		if err := k.client.List(ctx, &pods, client.InNamespace(k.namespace),
+			client.MatchingLabelsSelector{Selector: state.selector}); err != nil {
+			result.ResponseTime = time.Since(start)
+			result.Success = false
+			result.Error = err
+			result.Message = fmt.Sprintf("Kubernetes check failed to list pods for %s: %v", k.target(), err)
+			return result, nil
+		}
+	}
+	result.ResponseTime = time.Since(start)
+
+	var ready int
+	var crashLooping []string
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if podReady(pod) {
+			ready++
+		}
+		if reason := k.crashLoopReason(pod); reason != "" {
+			crashLooping = append(crashLooping, fmt.Sprintf("%s (%s)", pod.Name, reason))
+		}
+	}
+
+	var summaries []string
+	if state.summary != "" {
+		summaries = append(summaries, state.summary)
+	}
+	summaries = append(summaries, fmt.Sprintf("pods ready: %d/%d", ready, len(pods.Items)))
+
+	result.Details = map[string]interface{}{
+		"kind":         k.kind,
+		"name":         k.name,
+		"namespace":    k.namespace,
+		"pods":         len(pods.Items),
+		"readyPods":    ready,
+		"crashLooping": len(crashLooping),
+	}
+
+	var failures []string
+	if state.failure != "" {
+		failures = append(failures, state.failure)
+	}
+	if k.kind == KindPod && ready < k.minReady {
+		failures = append(failures, fmt.Sprintf("%d ready pod(s), expected at least %d", ready, k.minReady))
+	}
+	if len(crashLooping) > 0 {
+		failures = append(failures, fmt.Sprintf("%d crash-looping pod(s): %s", len(crashLooping), listPreview(crashLooping)))
+	}
+
+	summary := strings.Join(summaries, ", ")
+	switch {
+	case len(failures) > 0:
+		result.Success = false
+		result.Message = fmt.Sprintf("Kubernetes check failed for %s, %s (%s, response time: %v)",
+			k.target(), strings.Join(failures, "; "), summary, result.ResponseTime)
+	case state.degraded != "":
+		result.Success = true
+		result.Degraded = true
+		result.Message = fmt.Sprintf("Kubernetes check degraded for %s, %s (%s, response time: %v)",
+			k.target(), state.degraded, summary, result.ResponseTime)
+	default:
+		result.Success = true
+		result.Message = fmt.Sprintf("Kubernetes check successful for %s (%s, response time: %v)", k.target(), summary, result.ResponseTime)
+	}
+	return result, nil
+}
+
+// checkService counts ready endpoints across the EndpointSlices backing the Service
+func (k *KubernetesDriver) checkService(ctx context.Context) (*workloadState, error) {
+	var svc corev1.Service
+	if err := k.client.Get(ctx, types.NamespacedName{Namespace: k.namespace, Name: k.name}, &svc); err != nil {
+		return nil, err
+	}
+
+	var endpointSlices discoveryv1.EndpointSliceList
+	if err := k.client.List(ctx, &endpointSlices, client.InNamespace(k.namespace),
+		client.MatchingLabels{discoveryv1.LabelServiceName: k.name}); err != nil {
+		return nil, fmt.Errorf("failed to list EndpointSlices: %w", err)
+	}
+
+	// The same address may appear in one slice per address family or port set
+	readyAddresses := map[string]bool{}
+	notReady := map[string]bool{}
+	for _, slice := range endpointSlices.Items {
+		for _, ep := range slice.Endpoints {
+			if len(ep.Addresses) == 0 {
+				continue
+			}
+			address := ep.Addresses[0]
+			// A nil ready condition means the endpoint should be treated as ready
+			if ep.Conditions.Ready == nil || *ep.Conditions.Ready {
+				readyAddresses[address] = true
+			} else {
+				notReady[address] = true
+			}
+		}
+	}
+	for address := range readyAddresses {
+		delete(notReady, address)
+	}
+
+	state := &workloadState{
+		summary:  fmt.Sprintf("ready endpoints: %d, not ready: %d", len(readyAddresses), len(notReady)),
+		selector: labels.SelectorFromSet(svc.Spec.Selector),
+	}
+	if len(readyAddresses) < k.minReady {
+		state.failure = fmt.Sprintf("%d ready endpoint(s), expected at least %d", len(readyAddresses), k.minReady)
+	}
+	return state, nil
+}
+
+func (k *KubernetesDriver) checkDeployment(ctx context.Context) (*workloadState, error) {
+	var deploy appsv1.Deployment
+	if err := k.client.Get(ctx, types.NamespacedName{Namespace: k.namespace, Name: k.name}, &deploy); err != nil {
+		return nil, err
+	}
+	selector, err := metav1.LabelSelectorAsSelector(deploy.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid deployment selector: %w", err)
+	}
+
+	desired := replicasOrDefault(deploy.Spec.Replicas)
+	state := replicaState(desired, deploy.Status.AvailableReplicas, "available", selector)
+	state.summary += fmt.Sprintf(", updated: %d", deploy.Status.UpdatedReplicas)
+	for _, cond := range deploy.Status.Conditions {
+		if cond.Type == appsv1.DeploymentProgressing && cond.Reason == "ProgressDeadlineExceeded" {
+			if state.failure != "" {
+				state.failure += "; "
+			}
+			state.failure += "rollout exceeded its progress deadline"
+		}
+	}
+	return state, nil
+}
+
+func (k *KubernetesDriver) checkStatefulSet(ctx context.Context) (*workloadState, error) {
+	var sts appsv1.StatefulSet
+	if err := k.client.Get(ctx, types.NamespacedName{Namespace: k.namespace, Name: k.name}, &sts); err != nil {
+		return nil, err
+	}
+	selector, err := metav1.LabelSelectorAsSelector(sts.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid statefulset selector: %w", err)
+	}
+
+	return replicaState(replicasOrDefault(sts.Spec.Replicas), sts.Status.ReadyReplicas, "ready", selector), nil
+}
+
+// replicaState fails workloads without any replica up and degrades partially available ones
+func replicaState(desired, up int32, adjective string, selector labels.Selector) *workloadState {
+	state := &workloadState{
+		summary:  fmt.Sprintf("%s replicas: %d/%d", adjective, up, desired),
+		selector: selector,
+	}
+	switch {
+	case desired > 0 && up == 0:
+		state.failure = fmt.Sprintf("no %s replicas, expected %d", adjective, desired)
+	case up < desired:
+		state.degraded = fmt.Sprintf("%d of %d replicas %s", up, desired, adjective)
+	}
+	return state
+}
+
+func replicasOrDefault(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
+
+func podReady(pod *corev1.Pod) bool {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// crashLoopReason explains why a pod counts as crash-looping, or returns ""
+func (k *KubernetesDriver) crashLoopReason(pod *corev1.Pod) string {
+	statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, cs := range statuses {
+		if cs.State.Waiting != nil && cs.State.Waiting.Reason == "CrashLoopBackOff" {
+			return fmt.Sprintf("container %s in CrashLoopBackOff, %d restarts", cs.Name, cs.RestartCount)
+		}
+		if k.maxRestarts != nil && cs.RestartCount > *k.maxRestarts {
+			return fmt.Sprintf("container %s restarted %d times", cs.Name, cs.RestartCount)
+		}
+	}
+	return ""
+}
+
+func (k *KubernetesDriver) target() string {
+	if k.kind == KindPod {
+		return fmt.Sprintf("pods %s/%s", k.namespace, k.selector)
+	}
+	return fmt.Sprintf("%s %s/%s", k.kind, k.namespace, k.name)
+}
+
+func (k *KubernetesDriver) GetEndpoint() string {
+	return k.endpoint
+}
+
+func (k *KubernetesDriver) GetType() string {
+	return "kubernetes"
+}
@@ -0,0 +1,201 @@
+package driver
+
+import (
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	v1 "github.com/LiciousTech/endpoint-monitoring-operator/api/v1alpha1"
+)
+
+func testPod(name, app string, ready bool, waiting string, restarts int32) *corev1.Pod {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	cs := corev1.ContainerStatus{Name: "main", RestartCount: restarts}
+	if waiting != "" {
+		cs.State.Waiting = &corev1.ContainerStateWaiting{Reason: waiting}
+	}
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "shop", Labels: map[string]string{"app": app}},
+		Status: corev1.PodStatus{
+			Conditions:        []corev1.PodCondition{{Type: corev1.PodReady, Status: status}},
+			ContainerStatuses: []corev1.ContainerStatus{cs},
+		},
+	}
+}
+
+func testEndpointSlice(name, service string, ready map[string]bool) *discoveryv1.EndpointSlice {
+	slice := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "shop",
+			Labels:    map[string]string{discoveryv1.LabelServiceName: service},
+		},
+		AddressType: discoveryv1.AddressTypeIPv4,
+	}
+	for address, r := range ready {
+		slice.Endpoints = append(slice.Endpoints, discoveryv1.Endpoint{
+			Addresses:  []string{address},
+			Conditions: discoveryv1.EndpointConditions{Ready: &r},
+		})
+	}
+	return slice
+}
+
+func TestKubernetesDriver(t *testing.T) {
+	replicas := func(v int32) *int32 { return &v }
+	selector := func(app string) *metav1.LabelSelector {
+		return &metav1.LabelSelector{MatchLabels: map[string]string{"app": app}}
+	}
+
+	objects := []client.Object{
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "checkout", Namespace: "shop"},
+			Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": "checkout"}},
+		},
+		testEndpointSlice("checkout-a", "checkout", map[string]bool{"10.0.0.1": true, "10.0.0.2": false}),
+		testEndpointSlice("checkout-b", "checkout", map[string]bool{"10.0.0.1": true}),
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "search", Namespace: "shop"},
+			Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": "search"}},
+		},
+		testEndpointSlice("search-a", "search", map[string]bool{"10.0.1.1": false}),
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "checkout", Namespace: "shop"},
+			Spec:       appsv1.DeploymentSpec{Replicas: replicas(3), Selector: selector("checkout")},
+			Status:     appsv1.DeploymentStatus{AvailableReplicas: 2, UpdatedReplicas: 3},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "search", Namespace: "shop"},
+			Spec:       appsv1.DeploymentSpec{Replicas: replicas(1), Selector: selector("search")},
+		},
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "cache", Namespace: "shop"},
+			Spec:       appsv1.StatefulSetSpec{Replicas: replicas(2), Selector: selector("cache")},
+			Status:     appsv1.StatefulSetStatus{ReadyReplicas: 2},
+		},
+		testPod("checkout-1", "checkout", true, "", 0),
+		testPod("checkout-2", "checkout", true, "", 7),
+		testPod("checkout-3", "checkout", false, "ContainerCreating", 0),
+		testPod("search-1", "search", false, "CrashLoopBackOff", 12),
+		testPod("cache-0", "cache", true, "", 0),
+		testPod("cache-1", "cache", true, "", 0),
+	}
+	c := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(objects...).Build()
+
+	tests := []struct {
+		name      string
+		endpoint  string
+		check     *v1.KubernetesCheck
+		success   bool
+		degraded  bool
+		contained string
+	}{
+		{
+			name:      "service with ready endpoints",
+			endpoint:  "service/checkout",
+			success:   true,
+			contained: "ready endpoints: 1, not ready: 1, pods ready: 2/3",
+		},
+		{
+			name:      "service below minReady",
+			endpoint:  "services/checkout",
+			check:     &v1.KubernetesCheck{MinReady: replicas(2)},
+			contained: "1 ready endpoint(s), expected at least 2",
+		},
+		{
+			name:      "service without ready endpoints",
+			endpoint:  "service/search",
+			contained: "0 ready endpoint(s), expected at least 1",
+		},
+		{
+			name:      "partially available deployment",
+			endpoint:  "deployment/checkout",
+			success:   true,
+			degraded:  true,
+			contained: "2 of 3 replicas available",
+		},
+		{
+			name:      "restarts above maxRestarts",
+			endpoint:  "deployment/checkout",
+			check:     &v1.KubernetesCheck{MaxRestarts: replicas(5)},
+			contained: "1 crash-looping pod(s): checkout-2 (container main restarted 7 times)",
+		},
+		{
+			name:      "crash-looping deployment",
+			endpoint:  "Deployment/search",
+			contained: "no available replicas, expected 1; 1 crash-looping pod(s): search-1 (container main in CrashLoopBackOff",
+		},
+		{
+			name:      "ready statefulset",
+			endpoint:  "statefulset/cache",
+			success:   true,
+			contained: "ready replicas: 2/2, pods ready: 2/2",
+		},
+		{
+			name:      "pods by selector",
+			endpoint:  "pod",
+			check:     &v1.KubernetesCheck{Selector: selector("checkout"), MinReady: replicas(2)},
+			success:   true,
+			contained: "pods ready: 2/3",
+		},
+		{
+			name:      "pods below minReady",
+			endpoint:  "pods",
+			check:     &v1.KubernetesCheck{Selector: selector("checkout"), MinReady: replicas(3)},
+			contained: "2 ready pod(s), expected at least 3",
+		},
+		{
+			name:      "missing object",
+			endpoint:  "deployment/missing",
+			contained: "not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := NewKubernetesDriver(c, tt.endpoint, "shop", tt.check, false)
+			if err != nil {
+				t.Fatalf("unexpected error creating driver: %v", err)
+			}
+			result, err := d.Check()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Success != tt.success || result.Degraded != tt.degraded {
+				t.Fatalf("expected success=%t degraded=%t, got %t/%t: %s",
+					tt.success, tt.degraded, result.Success, result.Degraded, result.Message)
+			}
+			if tt.contained != "" && !strings.Contains(result.Message, tt.contained) {
+				t.Errorf("expected message to contain %q, got: %s", tt.contained, result.Message)
+			}
+		})
+	}
+
+	for _, endpoint := range []string{"deployment", "pod", "cronjob/nightly"} {
+		if _, err := NewKubernetesDriver(c, endpoint, "shop", nil, false); err == nil {
+			t.Errorf("expected endpoint %q to be rejected", endpoint)
+		}
+	}
+
+	other := &v1.KubernetesCheck{Namespace: "shop"}
+	if _, err := NewKubernetesDriver(c, "deployment/checkout", "monitoring", other, false); err == nil {
+		t.Error("expected a target in another namespace to be rejected without the opt-in")
+	}
+	d, err := NewKubernetesDriver(c, "deployment/checkout", "monitoring", other, true)
+	if err != nil {
+		t.Fatalf("unexpected error creating cross-namespace driver: %v", err)
+	}
+	if result, err := d.Check(); err != nil || !result.Success {
+		t.Errorf("expected the opted-in cross-namespace check to succeed, got %+v, %v", result, err)
+	}
+}
@@ -0,0 +1,255 @@
+package driver
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+
+	v1 "github.com/LiciousTech/endpoint-monitoring-operator/api/v1alpha1"
+)
+
+const defaultMySQLTLSMode = "preferred"
+
+// mysqlErrParse is returned by servers that do not know SHOW REPLICA STATUS (MariaDB, MySQL < 8.0.22)
+const mysqlErrParse = 1064
+
+type MySQLDriver struct {
+	endpoint       string
+	config         *mysql.Config
+	query          string
+	expectedResult string
+	expectedRole   string
+	maxLag         time.Duration
+	timeout        time.Duration
+}
+
+func NewMySQLDriver(endpoint string, check *v1.MysqlCheck, creds *Credentials) (Driver, error) {
+	if endpoint == "" {
+		return nil, fmt.Errorf("endpoint cannot be empty")
+	}
+	if check == nil {
+		check = &v1.MysqlCheck{}
+	}
+
+	m := &MySQLDriver{
+		endpoint:       endpoint,
+		query:          check.Query,
+		expectedResult: check.ExpectedResult,
+		expectedRole:   check.ExpectedRole,
+		timeout:        defaultSQLTimeout,
+	}
+	if m.query == "" {
+		m.query = defaultSQLQuery
+	}
+	switch m.expectedRole {
+	case "", RolePrimary, RoleStandby:
+	default:
+		return nil, fmt.Errorf("unsupported expectedRole %q, expected primary or standby", m.expectedRole)
+	}
+	if check.MaxReplicationLag != nil {
+		m.maxLag = check.MaxReplicationLag.Duration
+	}
+	if check.ConnectTimeout != nil && check.ConnectTimeout.Duration > 0 {
+		m.timeout = check.ConnectTimeout.Duration
+	}
+
+	tlsMode := check.TLSMode
+	if tlsMode == "" {
+		tlsMode = defaultMySQLTLSMode
+	}
+	switch tlsMode {
+	case "false", "preferred", "true", "skip-verify":
+	default:
+		return nil, fmt.Errorf("unsupported tlsMode %q", tlsMode)
+	}
+
+	addr := endpoint
+	if _, _, err := net.SplitHostPort(endpoint); err != nil {
+		addr = net.JoinHostPort(endpoint, "3306")
+	}
+
+	// Parsing a DSN is the only way to apply the driver's named TLS modes
+	cfg, err := mysql.ParseDSN("tcp(" + addr + ")/?tls=" + tlsMode)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mysql configuration: %w", err)
+	}
+	cfg.DBName = check.Database
+	cfg.Timeout = m.timeout
+	cfg.ReadTimeout = m.timeout
+	cfg.WriteTimeout = m.timeout
+	if creds != nil {
+		cfg.User = creds.Username
+		cfg.Passwd = creds.Password
+	}
+	m.config = cfg
+
+	return m, nil
+}
+
+func (m *MySQLDriver) Check() (*CheckResult, error) {
+	start := time.Now()
+	result := &CheckResult{}
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+	defer cancel()
+
+	connector, err := mysql.NewConnector(m.config)
+	if err != nil {
+		result.ResponseTime = time.Since(start)
+		result.Success = false
+		result.Error = err
+		result.Message = fmt.Sprintf("MySQL check failed: %v", err)
+		return result, nil
+	}
+	db := sql.OpenDB(connector)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	value, err := queryScalar(ctx, db, m.query)
+	duration := time.Since(start)
+	result.ResponseTime = duration
+	if err != nil {
+		result.Success = false
+		result.Error = err
+		result.Message = fmt.Sprintf("MySQL check failed (response time: %v): %v", duration, err)
+		return result, nil
+	}
+
+	var readOnly bool
+	if err := db.QueryRowContext(ctx, "SELECT @@global.read_only").Scan(&readOnly); err != nil {
+		result.Success = false
+		result.Error = err
+		result.Message = fmt.Sprintf("MySQL check failed to determine read_only: %v", err)
+		return result, nil
+	}
+	role := RolePrimary
+	if readOnly {
+		role = RoleStandby
+	}
+
+	result.Details = map[string]interface{}{
+		"result":   value,
+		"role":     role,
+		"readOnly": readOnly,
+	}
+	summary := fmt.Sprintf("role: %s, result: %q", role, value)
+
+	// Replica status needs the REPLICATION CLIENT privilege, so only ask when maxReplicationLag is set
+	var replica bool
+	var lag *time.Duration
+	if m.maxLag > 0 {
+		var err error
+		replica, lag, err = replicaLag(ctx, db)
+		if err != nil {
+			result.Success = false
+			result.Error = err
+			result.Message = fmt.Sprintf("MySQL check failed to read replica status: %v", err)
+			return result, nil
+		}
+		switch {
+		case lag != nil:
+			result.Details["replicationLag"] = lag.Seconds()
+			summary += fmt.Sprintf(", replication lag: %v", *lag)
+		case replica:
+			summary += ", replication not running"
+		}
+		result.Details["replica"] = replica
+	}
+
+	switch {
+	case m.expectedResult != "" && value != m.expectedResult:
+		result.Success = false
+		result.Message = fmt.Sprintf("MySQL check failed, query returned %q, expected %q (%s, response time: %v)",
+			value, m.expectedResult, summary, duration)
+	case m.expectedRole != "" && role != m.expectedRole:
+		result.Success = false
+		result.Message = fmt.Sprintf("MySQL check failed, server is %s, expected %s (%s, response time: %v)",
+			role, m.expectedRole, summary, duration)
+	case m.maxLag > 0 && replica && lag == nil:
+		// Seconds_Behind_Source is NULL while the replication threads are stopped
+		result.Success = false
+		result.Message = fmt.Sprintf("MySQL check failed, replication is not running (%s, response time: %v)", summary, duration)
+	case m.maxLag > 0 && lag != nil && *lag > m.maxLag:
+		result.Success = false
+		result.Message = fmt.Sprintf("MySQL check failed, replication lag %v exceeds %v (%s, response time: %v)",
+			*lag, m.maxLag, summary, duration)
+	default:
+		result.Success = true
+		result.Message = fmt.Sprintf("MySQL check successful (%s, response time: %v)", summary, duration)
+	}
+
+	return result, nil
+}
+
+// replicaLag reports whether the server replicates from a source and, when the replication
+// threads are running, how far behind it is
+func replicaLag(ctx context.Context, db *sql.DB) (bool, *time.Duration, error) {
+	row, err := queryRow(ctx, db, "SHOW REPLICA STATUS")
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrParse {
+		row, err = queryRow(ctx, db, "SHOW SLAVE STATUS")
+	}
+	if err != nil || row == nil {
+		return false, nil, err
+	}
+
+	seconds, ok := row["Seconds_Behind_Source"]
+	if !ok {
+		seconds = row["Seconds_Behind_Master"]
+	}
+	if !seconds.Valid {
+		return true, nil, nil
+	}
+	n, err := strconv.ParseInt(seconds.String, 10, 64)
+	if err != nil {
+		return true, nil, fmt.Errorf("invalid replication lag %q: %w", seconds.String, err)
+	}
+	lag := time.Duration(n) * time.Second
+	return true, &lag, nil
+}
+
+// queryRow runs query and returns the first row keyed by column name, or nil when there are no rows
+func queryRow(ctx context.Context, db *sql.DB, query string) (map[string]sql.NullString, error) {
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	if !rows.Next() {
+		return nil, rows.Err()
+	}
+
+	values := make([]sql.NullString, len(columns))
+	targets := make([]interface{}, len(columns))
+	for i := range values {
+		targets[i] = &values[i]
+	}
+	if err := rows.Scan(targets...); err != nil {
+		return nil, err
+	}
+
+	row := make(map[string]sql.NullString, len(columns))
+	for i, column := range columns {
+		row[column] = values[i]
+	}
+	return row, nil
+}
+
+func (m *MySQLDriver) GetEndpoint() string {
+	return m.endpoint
+}
+
+func (m *MySQLDriver) GetType() string {
+	return "mysql"
+}
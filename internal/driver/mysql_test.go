@@ -0,0 +1,304 @@
+package driver
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/binary"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/LiciousTech/endpoint-monitoring-operator/api/v1alpha1"
+)
+
+// fakeMySQL is an in-process stand-in speaking just enough of the MySQL client/server
+// protocol (native password auth and text-protocol queries) to exercise the driver.
+type fakeMySQL struct {
+	password string
+	readOnly bool
+	// replicaStatus is the Seconds_Behind_Source column; nil means not a replica and
+	// "NULL" means replication is stopped
+	replicaStatus *string
+	// legacy servers only understand SHOW SLAVE STATUS
+	legacy bool
+	// results maps a query to the value of its single result column
+	results map[string]string
+}
+
+var mysqlSalt = []byte("abcdefghij0123456789")
+
+func (f *fakeMySQL) start(t *testing.T) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go f.serve(conn)
+		}
+	}()
+	return ln.Addr().String()
+}
+
+func (f *fakeMySQL) serve(conn net.Conn) {
+	defer conn.Close()
+
+	// protocol 41 | long password | transactions | secure connection | plugin auth
+	const capabilities = 0x0001 | 0x0200 | 0x2000 | 0x8000 | 0x80000
+	greeting := []byte{10}
+	greeting = append(greeting, "8.0.36-fake\x00"...)
+	greeting = append(greeting, 1, 0, 0, 0)
+	greeting = append(greeting, mysqlSalt[:8]...)
+	greeting = append(greeting, 0)
+	greeting = binary.LittleEndian.AppendUint16(greeting, capabilities&0xffff)
+	greeting = append(greeting, 45, 2, 0)
+	greeting = binary.LittleEndian.AppendUint16(greeting, capabilities>>16)
+	greeting = append(greeting, 21)
+	greeting = append(greeting, make([]byte, 10)...)
+	greeting = append(greeting, mysqlSalt[8:]...)
+	greeting = append(greeting, 0)
+	greeting = append(greeting, "mysql_native_password\x00"...)
+	writeMySQLPacket(conn, 0, greeting)
+
+	_, login, err := readMySQLPacket(conn)
+	if err != nil {
+		return
+	}
+	// capabilities, max packet, charset and filler precede the NUL-terminated user name
+	rest := login[32:]
+	rest = rest[bytes.IndexByte(rest, 0)+1:]
+	authResponse := rest[1 : 1+int(rest[0])]
+	if !bytes.Equal(authResponse, nativePasswordScramble(mysqlSalt, f.password)) {
+		writeMySQLPacket(conn, 2, append([]byte{0xff, 0x15, 0x04}, "#28000Access denied for user"...))
+		return
+	}
+	writeMySQLPacket(conn, 2, []byte{0, 0, 0, 2, 0, 0, 0})
+
+	for {
+		_, packet, err := readMySQLPacket(conn)
+		if err != nil || len(packet) == 0 || packet[0] == 0x01 {
+			return
+		}
+		if packet[0] != 0x03 {
+			continue
+		}
+		query := string(packet[1:])
+
+		switch {
+		case query == "SELECT @@global.read_only":
+			value := "0"
+			if f.readOnly {
+				value = "1"
+			}
+			writeMySQLResultSet(conn, []string{"@@global.read_only"}, [][]*string{{&value}})
+		case query == "SHOW REPLICA STATUS" && f.legacy:
+			writeMySQLPacket(conn, 1, append([]byte{0xff, 0x28, 0x04}, "#42000You have an error in your SQL syntax"...))
+		case query == "SHOW REPLICA STATUS" || query == "SHOW SLAVE STATUS":
+			column := "Seconds_Behind_Source"
+			if query == "SHOW SLAVE STATUS" {
+				column = "Seconds_Behind_Master"
+			}
+			var rows [][]*string
+			if f.replicaStatus != nil {
+				host := "db-primary"
+				lag := f.replicaStatus
+				if *lag == "NULL" {
+					lag = nil
+				}
+				rows = append(rows, []*string{&host, lag})
+			}
+			writeMySQLResultSet(conn, []string{"Source_Host", column}, rows)
+		default:
+			value, ok := f.results[query]
+			if !ok {
+				writeMySQLPacket(conn, 1, append([]byte{0xff, 0x7a, 0x04}, "#42S02Table 'shop.missing' doesn't exist"...))
+				continue
+			}
+			writeMySQLResultSet(conn, []string{"value"}, [][]*string{{&value}})
+		}
+	}
+}
+
+func nativePasswordScramble(salt []byte, password string) []byte {
+	stage1 := sha1.Sum([]byte(password))
+	stage2 := sha1.Sum(stage1[:])
+	h := sha1.New()
+	h.Write(salt)
+	h.Write(stage2[:])
+	scramble := h.Sum(nil)
+	for i := range scramble {
+		scramble[i] ^= stage1[i]
+	}
+	return scramble
+}
+
+func readMySQLPacket(r io.Reader) (byte, []byte, error) {
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return 0, nil, err
+	}
+	length := int(header[0]) | int(header[1])<<8 | int(header[2])<<16
+	body := make([]byte, length)
+	_, err := io.ReadFull(r, body)
+	return header[3], body, err
+}
+
+func writeMySQLPacket(w io.Writer, seq byte, body []byte) {
+	header := []byte{byte(len(body)), byte(len(body) >> 8), byte(len(body) >> 16), seq}
+	_, _ = w.Write(append(header, body...))
+}
+
+func writeMySQLResultSet(w io.Writer, columns []string, rows [][]*string) {
+	lenenc := func(b []byte, s string) []byte {
+		return append(append(b, byte(len(s))), s...)
+	}
+	eof := []byte{0xfe, 0, 0, 2, 0}
+
+	seq := byte(1)
+	writeMySQLPacket(w, seq, []byte{byte(len(columns))})
+	for _, column := range columns {
+		seq++
+		def := lenenc(nil, "def")
+		def = lenenc(def, "")
+		def = lenenc(def, "")
+		def = lenenc(def, "")
+		def = lenenc(def, column)
+		def = lenenc(def, column)
+		// fixed fields: charset utf8mb4, length, VAR_STRING, flags, decimals, filler
+		def = append(def, 0x0c, 45, 0, 0, 1, 0, 0, 0xfd, 0, 0, 0, 0, 0)
+		writeMySQLPacket(w, seq, def)
+	}
+	seq++
+	writeMySQLPacket(w, seq, eof)
+	for _, row := range rows {
+		seq++
+		var data []byte
+		for _, value := range row {
+			if value == nil {
+				data = append(data, 0xfb)
+				continue
+			}
+			data = lenenc(data, *value)
+		}
+		writeMySQLPacket(w, seq, data)
+	}
+	seq++
+	writeMySQLPacket(w, seq, eof)
+}
+
+func TestMySQLDriver(t *testing.T) {
+	lagging, stopped := "120", "NULL"
+	results := map[string]string{"SELECT 1": "1", "SELECT COUNT(*) FROM orders": "42"}
+
+	primary := (&fakeMySQL{password: "s3cret", results: results}).start(t)
+	replica := (&fakeMySQL{password: "s3cret", readOnly: true, replicaStatus: &lagging, results: results}).start(t)
+	legacyReplica := (&fakeMySQL{password: "s3cret", readOnly: true, replicaStatus: &stopped, legacy: true, results: results}).start(t)
+
+	creds := &Credentials{Username: "monitor", Password: "s3cret"}
+	maxLag := &metav1.Duration{Duration: time.Minute}
+
+	tests := []struct {
+		name      string
+		endpoint  string
+		check     v1.MysqlCheck
+		creds     *Credentials
+		success   bool
+		contained string
+	}{
+		{
+			name:      "default probe query",
+			endpoint:  primary,
+			creds:     creds,
+			success:   true,
+			contained: "role: primary",
+		},
+		{
+			name:     "scalar assertion",
+			endpoint: primary,
+			check:    v1.MysqlCheck{Query: "SELECT COUNT(*) FROM orders", ExpectedResult: "42", ExpectedRole: "primary"},
+			creds:    creds,
+			success:  true,
+		},
+		{
+			name:      "query error",
+			endpoint:  primary,
+			check:     v1.MysqlCheck{Query: "SELECT * FROM missing"},
+			creds:     creds,
+			contained: "doesn't exist",
+		},
+		{
+			name:      "login rejected",
+			endpoint:  primary,
+			creds:     &Credentials{Username: "monitor", Password: "wrong"},
+			contained: "Access denied",
+		},
+		{
+			name:      "read_only primary",
+			endpoint:  replica,
+			check:     v1.MysqlCheck{ExpectedRole: "primary"},
+			creds:     creds,
+			contained: "server is standby, expected primary",
+		},
+		{
+			name:      "replica without lag threshold skips replica status",
+			endpoint:  replica,
+			check:     v1.MysqlCheck{ExpectedRole: "standby"},
+			creds:     creds,
+			success:   true,
+			contained: `role: standby, result: "1", response`,
+		},
+		{
+			name:      "replica within threshold reports lag",
+			endpoint:  replica,
+			check:     v1.MysqlCheck{ExpectedRole: "standby", MaxReplicationLag: &metav1.Duration{Duration: 5 * time.Minute}},
+			creds:     creds,
+			success:   true,
+			contained: "replication lag: 2m0s",
+		},
+		{
+			name:      "replica lag over threshold",
+			endpoint:  replica,
+			check:     v1.MysqlCheck{MaxReplicationLag: maxLag},
+			creds:     creds,
+			contained: "replication lag 2m0s exceeds 1m0s",
+		},
+		{
+			name:      "stopped replication on legacy server",
+			endpoint:  legacyReplica,
+			check:     v1.MysqlCheck{MaxReplicationLag: maxLag},
+			creds:     creds,
+			contained: "replication is not running",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.check.TLSMode = "false"
+			d, err := NewMySQLDriver(tt.endpoint, &tt.check, tt.creds)
+			if err != nil {
+				t.Fatalf("unexpected error creating driver: %v", err)
+			}
+			result, err := d.Check()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Success != tt.success {
+				t.Fatalf("expected success=%t, got %t: %s", tt.success, result.Success, result.Message)
+			}
+			if tt.contained != "" && !strings.Contains(result.Message, tt.contained) {
+				t.Errorf("expected message to contain %q, got: %s", tt.contained, result.Message)
+			}
+		})
+	}
+}
@@ -4,13 +4,34 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"slices"
 	"strings"
 	"time"
+
+	v1 "github.com/LiciousTech/endpoint-monitoring-operator/api/v1alpha1"
 )
 
 type OpenSearchDriver struct {
-	endpoint string
-	client   *http.Client
+	// driverType is "opensearch" or "elasticsearch", whichever the monitor named
+	driverType string
+	endpoint   string
+	client     *http.Client
+	policy     openSearchPolicy
+	creds      *Credentials
+	apiKey     string
+}
+
+// openSearchPolicy decides how cluster and index health map to a check status
+type openSearchPolicy struct {
+	accepted               []string
+	degraded               []string
+	minNodes               *int32
+	minDataNodes           *int32
+	maxUnassignedShards    *int32
+	maxPendingTasks        *int32
+	minActiveShardsPercent *int32
+	indices                []string
 }
 
 type OpenSearchClusterHealth struct {
@@ -31,31 +52,51 @@ type OpenSearchClusterHealth struct {
 	ActiveShardsPercentAsNumber float64 `json:"active_shards_percent_as_number"`
 }
 
-func NewOpenSearchDriver(endpoint string) (Driver, error) {
+func NewOpenSearchDriver(driverType, endpoint string, check *v1.OpenSearchCheck, creds *Credentials, apiKey string) (Driver, error) {
 	if endpoint == "" {
 		return nil, fmt.Errorf("endpoint cannot be empty")
 	}
+	if creds != nil && apiKey != "" {
+		return nil, fmt.Errorf("credentialsSecretRef and apiKeySecretRef are mutually exclusive")
+	}
 
 	endpoint = strings.TrimSuffix(endpoint, "/")
 
-	return &OpenSearchDriver{
-		endpoint: endpoint,
+	o := &OpenSearchDriver{
+		driverType: driverType,
+		endpoint:   endpoint,
 		client: &http.Client{
 			Timeout: 30 * time.Second,
 		},
-	}, nil
+		policy: openSearchPolicy{accepted: []string{"green"}},
+		creds:  creds,
+		apiKey: apiKey,
+	}
+	if check != nil {
+		if len(check.AcceptedStatuses) > 0 {
+			o.policy.accepted = lowerAll(check.AcceptedStatuses)
+		}
+		o.policy.degraded = lowerAll(check.DegradedStatuses)
+		o.policy.minNodes = check.MinNodes
+		o.policy.minDataNodes = check.MinDataNodes
+		o.policy.maxUnassignedShards = check.MaxUnassignedShards
+		o.policy.maxPendingTasks = check.MaxPendingTasks
+		o.policy.minActiveShardsPercent = check.MinActiveShardsPercent
+		o.policy.indices = check.Indices
+	}
+
+	return o, nil
 }
 
 func (o *OpenSearchDriver) Check() (*CheckResult, error) {
 	start := time.Now()
 
-	healthURL := o.endpoint + "/_cluster/health"
-
-	resp, err := o.client.Get(healthURL)
+	health, statusCode, err := o.health("")
 	duration := time.Since(start)
 
 	result := &CheckResult{
 		ResponseTime: duration,
+		StatusCode:   statusCode,
 	}
 
 	if err != nil {
@@ -65,48 +106,137 @@ func (o *OpenSearchDriver) Check() (*CheckResult, error) {
 		return result, nil
 	}
 
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
+	if statusCode != 200 {
 		result.Success = false
-		result.Message = fmt.Sprintf("OpenSearch check failed (status: %d, response time: %v)", resp.StatusCode, duration)
+		result.Message = fmt.Sprintf("OpenSearch check failed (status: %d, response time: %v)", statusCode, duration)
 		return result, nil
 	}
+	result.Details = toDetails(health)
+
+	var failures, warnings []string
+	classify := func(subject, status string) {
+		switch {
+		case slices.Contains(o.policy.accepted, status):
+		case slices.Contains(o.policy.degraded, status):
+			warnings = append(warnings, fmt.Sprintf("%s status is %s", subject, status))
+		default:
+			failures = append(failures, fmt.Sprintf("%s status is %s", subject, status))
+		}
+	}
 
-	var health OpenSearchClusterHealth
-	if err := json.NewDecoder(resp.Body).Decode(&health); err != nil {
-		result.Success = false
-		result.Error = err
-		result.Message = fmt.Sprintf("OpenSearch check failed to parse response: %v", err)
-		return result, nil
+	classify("cluster", strings.ToLower(health.Status))
+	failures = append(failures, o.policy.thresholdViolations(health)...)
+
+	if len(o.policy.indices) > 0 {
+		indices := make(map[string]string, len(o.policy.indices))
+		for _, index := range o.policy.indices {
+			indexHealth, statusCode, err := o.health(index)
+			switch {
+			case err != nil:
+				failures = append(failures, fmt.Sprintf("index %s: %v", index, err))
+			case statusCode == http.StatusNotFound:
+				failures = append(failures, fmt.Sprintf("index %s not found", index))
+			case statusCode != 200:
+				failures = append(failures, fmt.Sprintf("index %s: unexpected status %d", index, statusCode))
+			default:
+				status := strings.ToLower(indexHealth.Status)
+				indices[index] = status
+				classify("index "+index, status)
+			}
+		}
+		result.Details["indices"] = indices
 	}
+	duration = time.Since(start)
+	result.ResponseTime = duration
 
-	switch strings.ToLower(health.Status) {
-	case "green":
+	switch {
+	case len(failures) > 0:
+		result.Success = false
+		result.Message = fmt.Sprintf("OpenSearch cluster is unhealthy, %s (status: %s, nodes: %d, unassigned_shards: %d, response time: %v)",
+			strings.Join(append(failures, warnings...), "; "), health.Status, health.NumberOfNodes, health.UnassignedShards, duration)
+	case len(warnings) > 0:
+		result.Success = true
+		result.Degraded = true
+		result.Message = fmt.Sprintf("OpenSearch cluster has issues, %s (status: %s, nodes: %d, unassigned_shards: %d, response time: %v)",
+			strings.Join(warnings, "; "), health.Status, health.NumberOfNodes, health.UnassignedShards, duration)
+	default:
 		result.Success = true
 		result.Message = fmt.Sprintf("OpenSearch cluster is healthy (status: %s, nodes: %d, active_shards: %d, response time: %v)",
 			health.Status, health.NumberOfNodes, health.ActiveShards, duration)
-	case "yellow":
-		result.Success = false
-		result.Message = fmt.Sprintf("OpenSearch cluster has issues (status: %s, nodes: %d, unassigned_shards: %d, response time: %v)",
-			health.Status, health.NumberOfNodes, health.UnassignedShards, duration)
-	case "red":
-		result.Success = false
-		result.Message = fmt.Sprintf("OpenSearch cluster is unhealthy (status: %s, nodes: %d, unassigned_shards: %d, response time: %v)",
-			health.Status, health.NumberOfNodes, health.UnassignedShards, duration)
-	default:
-		result.Success = false
-		result.Message = fmt.Sprintf("OpenSearch cluster status unknown (status: %s, response time: %v)",
-			health.Status, duration)
 	}
 
 	return result, nil
 }
 
+// health fetches cluster health, or the health of a single index when index is set
+func (o *OpenSearchDriver) health(index string) (*OpenSearchClusterHealth, int, error) {
+	healthURL := o.endpoint + "/_cluster/health"
+	if index != "" {
+		healthURL += "/" + url.PathEscape(index)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, healthURL, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	switch {
+	case o.apiKey != "":
+		req.Header.Set("Authorization", "ApiKey "+o.apiKey)
+	case o.creds != nil:
+		req.SetBasicAuth(o.creds.Username, o.creds.Password)
+	}
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, resp.StatusCode, nil
+	}
+
+	var health OpenSearchClusterHealth
+	if err := json.NewDecoder(resp.Body).Decode(&health); err != nil {
+		return nil, resp.StatusCode, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return &health, resp.StatusCode, nil
+}
+
+// thresholdViolations lists the configured limits the cluster health falls outside of
+func (p openSearchPolicy) thresholdViolations(health *OpenSearchClusterHealth) []string {
+	var violations []string
+	if p.minNodes != nil && health.NumberOfNodes < int(*p.minNodes) {
+		violations = append(violations, fmt.Sprintf("%d node(s), expected at least %d", health.NumberOfNodes, *p.minNodes))
+	}
+	if p.minDataNodes != nil && health.NumberOfDataNodes < int(*p.minDataNodes) {
+		violations = append(violations, fmt.Sprintf("%d data node(s), expected at least %d", health.NumberOfDataNodes, *p.minDataNodes))
+	}
+	if p.maxUnassignedShards != nil && health.UnassignedShards > int(*p.maxUnassignedShards) {
+		violations = append(violations, fmt.Sprintf("%d unassigned shard(s), expected at most %d", health.UnassignedShards, *p.maxUnassignedShards))
+	}
+	if p.maxPendingTasks != nil && health.NumberOfPendingTasks > int(*p.maxPendingTasks) {
+		violations = append(violations, fmt.Sprintf("%d pending task(s), expected at most %d", health.NumberOfPendingTasks, *p.maxPendingTasks))
+	}
+	if p.minActiveShardsPercent != nil && health.ActiveShardsPercentAsNumber < float64(*p.minActiveShardsPercent) {
+		violations = append(violations, fmt.Sprintf("%.1f%% active shards, expected at least %d%%",
+			health.ActiveShardsPercentAsNumber, *p.minActiveShardsPercent))
+	}
+	return violations
+}
+
+func lowerAll(values []string) []string {
+	lowered := make([]string, len(values))
+	for i, v := range values {
+		lowered[i] = strings.ToLower(v)
+	}
+	return lowered
+}
+
 func (o *OpenSearchDriver) GetEndpoint() string {
 	return o.endpoint
 }
 
 func (o *OpenSearchDriver) GetType() string {
-	return "opensearch"
+	return o.driverType
 }
@@ -0,0 +1,129 @@
+package driver
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	v1 "github.com/LiciousTech/endpoint-monitoring-operator/api/v1alpha1"
+)
+
+// startOpenSearchServer serves cluster health with the given status and per-index
+// health for "logs" (green) and "metrics" (red). Requests must carry auth when set.
+func startOpenSearchServer(t *testing.T, status, auth string) string {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth != "" && r.Header.Get("Authorization") != auth {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		indexStatus := status
+		switch r.URL.Path {
+		case "/_cluster/health":
+		case "/_cluster/health/logs":
+			indexStatus = "green"
+		case "/_cluster/health/metrics":
+			indexStatus = "red"
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error":{"type":"index_not_found_exception"},"status":404}`)
+			return
+		}
+		fmt.Fprintf(w, `{"cluster_name":"search","status":"%s","number_of_nodes":3,"number_of_data_nodes":2,
+			"active_shards":18,"unassigned_shards":2,"number_of_pending_tasks":7,"active_shards_percent_as_number":90.0}`, indexStatus)
+	}))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func TestOpenSearchDriverPolicy(t *testing.T) {
+	green := startOpenSearchServer(t, "green", "")
+	yellow := startOpenSearchServer(t, "yellow", "")
+	secured := startOpenSearchServer(t, "green", "ApiKey c2VjcmV0")
+	limit := func(v int32) *int32 { return &v }
+
+	tests := []struct {
+		name      string
+		endpoint  string
+		check     *v1.OpenSearchCheck
+		apiKey    string
+		success   bool
+		degraded  bool
+		contained string
+	}{
+		{
+			name:      "green by default",
+			endpoint:  green,
+			success:   true,
+			contained: "OpenSearch cluster is healthy",
+		},
+		{
+			name:      "yellow fails by default",
+			endpoint:  yellow,
+			contained: "cluster status is yellow",
+		},
+		{
+			name:      "yellow accepted as degraded",
+			endpoint:  yellow,
+			check:     &v1.OpenSearchCheck{DegradedStatuses: []string{"Yellow"}},
+			success:   true,
+			degraded:  true,
+			contained: "OpenSearch cluster has issues, cluster status is yellow",
+		},
+		{
+			name:     "thresholds",
+			endpoint: green,
+			check: &v1.OpenSearchCheck{
+				MinNodes:               limit(3),
+				MinDataNodes:           limit(3),
+				MaxUnassignedShards:    limit(0),
+				MaxPendingTasks:        limit(5),
+				MinActiveShardsPercent: limit(95),
+			},
+			contained: "2 data node(s), expected at least 3; 2 unassigned shard(s), expected at most 0; " +
+				"7 pending task(s), expected at most 5; 90.0% active shards, expected at least 95%",
+		},
+		{
+			name:      "per-index health",
+			endpoint:  green,
+			check:     &v1.OpenSearchCheck{Indices: []string{"logs", "metrics", "traces"}},
+			contained: "index metrics status is red; index traces not found",
+		},
+		{
+			name:     "api key auth",
+			endpoint: secured,
+			apiKey:   "c2VjcmV0",
+			success:  true,
+		},
+		{
+			name:      "missing auth",
+			endpoint:  secured,
+			contained: "status: 401",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := NewOpenSearchDriver("elasticsearch", tt.endpoint, tt.check, nil, tt.apiKey)
+			if err != nil {
+				t.Fatalf("unexpected error creating driver: %v", err)
+			}
+			if got := d.GetType(); got != "elasticsearch" {
+				t.Errorf("expected type elasticsearch, got %q", got)
+			}
+			result, err := d.Check()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Success != tt.success || result.Degraded != tt.degraded {
+				t.Fatalf("expected success=%t degraded=%t, got %t/%t: %s",
+					tt.success, tt.degraded, result.Success, result.Degraded, result.Message)
+			}
+			if tt.contained != "" && !strings.Contains(result.Message, tt.contained) {
+				t.Errorf("expected message to contain %q, got: %s", tt.contained, result.Message)
+			}
+		})
+	}
+}
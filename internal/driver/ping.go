@@ -1,49 +1,292 @@
 package driver
 
 import (
+	"errors"
 	"fmt"
 	"net"
+	"os"
 	"time"
+
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+	"golang.org/x/net/ipv6"
+
+	v1 "github.com/LiciousTech/endpoint-monitoring-operator/api/v1alpha1"
+)
+
+const (
+	defaultPingCount      = 3
+	defaultPingInterval   = time.Second
+	defaultPingTimeout    = 2 * time.Second
+	defaultPingPacketSize = 56
+	maxPingPacketSize     = 65507
 )
 
 type PingDriver struct {
-	endpoint string
+	endpoint      string
+	count         int
+	interval      time.Duration
+	timeout       time.Duration
+	packetSize    int
+	maxPacketLoss *int32
+	ipVersion     string
+}
+
+// pingStats summarises the echo replies of a single check
+type pingStats struct {
+	sent     int
+	received int
+	min      time.Duration
+	max      time.Duration
+	total    time.Duration
+}
+
+func (s *pingStats) loss() float64 {
+	if s.sent == 0 {
+		return 100
+	}
+	return float64(s.sent-s.received) * 100 / float64(s.sent)
+}
+
+func (s *pingStats) avg() time.Duration {
+	if s.received == 0 {
+		return 0
+	}
+	return s.total / time.Duration(s.received)
 }
 
-func NewPingDriver(endpoint string) (Driver, error) {
+func NewPingDriver(endpoint string, check *v1.PingCheck) (Driver, error) {
 	if endpoint == "" {
 		return nil, fmt.Errorf("endpoint cannot be empty")
 	}
 
-	return &PingDriver{endpoint: endpoint}, nil
+	p := &PingDriver{
+		endpoint:   endpoint,
+		count:      defaultPingCount,
+		interval:   defaultPingInterval,
+		timeout:    defaultPingTimeout,
+		packetSize: defaultPingPacketSize,
+	}
+
+	if check != nil {
+		if check.Count > 0 {
+			p.count = check.Count
+		}
+		if check.Interval != nil && check.Interval.Duration > 0 {
+			p.interval = check.Interval.Duration
+		}
+		if check.Timeout != nil && check.Timeout.Duration > 0 {
+			p.timeout = check.Timeout.Duration
+		}
+		if check.PacketSize > 0 {
+			p.packetSize = check.PacketSize
+		}
+		p.maxPacketLoss = check.MaxPacketLoss
+		p.ipVersion = check.IPVersion
+	}
+
+	if p.packetSize > maxPingPacketSize {
+		return nil, fmt.Errorf("packetSize cannot exceed %d bytes", maxPingPacketSize)
+	}
+	if p.maxPacketLoss != nil && (*p.maxPacketLoss < 0 || *p.maxPacketLoss > 100) {
+		return nil, fmt.Errorf("maxPacketLoss must be between 0 and 100")
+	}
+	switch p.ipVersion {
+	case "", "ipv4", "ipv6":
+	default:
+		return nil, fmt.Errorf("unsupported ipVersion %q, expected ipv4 or ipv6", p.ipVersion)
+	}
+
+	return p, nil
 }
 
 func (p *PingDriver) Check() (*CheckResult, error) {
 	start := time.Now()
+	result := &CheckResult{}
 
-	// Simple connectivity check
-	conn, err := net.DialTimeout("tcp", p.endpoint+":80", 5*time.Second)
-	duration := time.Since(start)
+	network := "ip"
+	switch p.ipVersion {
+	case "ipv4":
+		network = "ip4"
+	case "ipv6":
+		network = "ip6"
+	}
 
-	result := &CheckResult{
-		ResponseTime: duration,
+	addr, err := net.ResolveIPAddr(network, p.endpoint)
+	if err != nil {
+		result.ResponseTime = time.Since(start)
+		result.Success = false
+		result.Error = err
+		result.Message = fmt.Sprintf("Ping check failed to resolve %s: %v", p.endpoint, err)
+		return result, nil
 	}
 
+	stats, err := p.ping(addr.IP)
 	if err != nil {
+		result.ResponseTime = time.Since(start)
 		result.Success = false
 		result.Error = err
 		result.Message = fmt.Sprintf("Ping check failed: %v", err)
 		return result, nil
 	}
 
-	defer conn.Close()
+	loss := stats.loss()
+	result.ResponseTime = stats.avg()
+	result.Details = map[string]interface{}{
+		"address":    addr.IP.String(),
+		"sent":       stats.sent,
+		"received":   stats.received,
+		"packetLoss": loss,
+		"minRtt":     stats.min.Seconds() * 1000,
+		"avgRtt":     stats.avg().Seconds() * 1000,
+		"maxRtt":     stats.max.Seconds() * 1000,
+	}
+
+	summary := fmt.Sprintf("address: %s, %d/%d received, %.1f%% packet loss, rtt min/avg/max: %v/%v/%v",
+		addr.IP, stats.received, stats.sent, loss, stats.min, stats.avg(), stats.max)
 
-	result.Success = true
-	result.Message = fmt.Sprintf("Ping check successful (response time: %v)", duration)
+	switch {
+	case stats.received == 0:
+		// Without replies there is no round-trip time; report how long the probe waited
+		result.ResponseTime = time.Since(start)
+		result.Success = false
+		result.Message = fmt.Sprintf("Ping check failed, no echo replies (%s)", summary)
+	case p.maxPacketLoss != nil && loss > float64(*p.maxPacketLoss):
+		result.Success = false
+		result.Message = fmt.Sprintf("Ping check failed, packet loss above %d%% (%s)", *p.maxPacketLoss, summary)
+	default:
+		result.Success = true
+		result.Message = fmt.Sprintf("Ping check successful (%s)", summary)
+	}
 
 	return result, nil
 }
 
+// ping sends p.count echo requests to ip and waits for each reply in turn
+func (p *PingDriver) ping(ip net.IP) (*pingStats, error) {
+	ipv6Target := ip.To4() == nil
+
+	conn, unprivileged, err := listenICMP(ipv6Target)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	var dst net.Addr = &net.IPAddr{IP: ip}
+	if unprivileged {
+		dst = &net.UDPAddr{IP: ip}
+	}
+
+	var echoType icmp.Type = ipv4.ICMPTypeEcho
+	protocol := 1 // ICMP for IPv4
+	if ipv6Target {
+		echoType = ipv6.ICMPTypeEchoRequest
+		protocol = 58 // ICMP for IPv6
+	}
+
+	// The kernel rewrites the identifier on datagram sockets, so it is only matched for raw sockets
+	id := os.Getpid() & 0xffff
+	payload := make([]byte, p.packetSize)
+	stats := &pingStats{}
+	reply := make([]byte, p.packetSize+512)
+
+	for seq := 0; seq < p.count; seq++ {
+		if seq > 0 {
+			time.Sleep(p.interval)
+		}
+
+		msg := icmp.Message{
+			Type: echoType,
+			Body: &icmp.Echo{ID: id, Seq: seq, Data: payload},
+		}
+		packet, err := msg.Marshal(nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build echo request: %w", err)
+		}
+
+		sentAt := time.Now()
+		if _, err := conn.WriteTo(packet, dst); err != nil {
+			return nil, fmt.Errorf("failed to send echo request: %w", err)
+		}
+		stats.sent++
+
+		ok, err := awaitEchoReply(conn, reply, protocol, id, seq, unprivileged, sentAt.Add(p.timeout))
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		rtt := time.Since(sentAt)
+
+		stats.received++
+		stats.total += rtt
+		if stats.min == 0 || rtt < stats.min {
+			stats.min = rtt
+		}
+		if rtt > stats.max {
+			stats.max = rtt
+		}
+	}
+
+	return stats, nil
+}
+
+// awaitEchoReply reads from conn until the reply for seq arrives or deadline passes
+func awaitEchoReply(conn *icmp.PacketConn, buf []byte, protocol, id, seq int, unprivileged bool,
+	deadline time.Time) (bool, error) {
+	if err := conn.SetReadDeadline(deadline); err != nil {
+		return false, err
+	}
+
+	for {
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				return false, nil
+			}
+			return false, fmt.Errorf("failed to read echo reply: %w", err)
+		}
+
+		msg, err := icmp.ParseMessage(protocol, buf[:n])
+		if err != nil {
+			continue
+		}
+		if msg.Type != ipv4.ICMPTypeEchoReply && msg.Type != ipv6.ICMPTypeEchoReply {
+			continue
+		}
+		echo, ok := msg.Body.(*icmp.Echo)
+		if !ok || echo.Seq != seq {
+			continue
+		}
+		// Raw sockets see every echo reply on the host, so the identifier must match too
+		if !unprivileged && echo.ID != id {
+			continue
+		}
+		return true, nil
+	}
+}
+
+// listenICMP opens an unprivileged ICMP datagram socket where the kernel allows
+// it and falls back to a raw socket, which requires CAP_NET_RAW.
+func listenICMP(ipv6Target bool) (*icmp.PacketConn, bool, error) {
+	datagram, raw, address := "udp4", "ip4:icmp", "0.0.0.0"
+	if ipv6Target {
+		datagram, raw, address = "udp6", "ip6:ipv6-icmp", "::"
+	}
+
+	if conn, err := icmp.ListenPacket(datagram, address); err == nil {
+		return conn, true, nil
+	}
+
+	conn, err := icmp.ListenPacket(raw, address)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to open ICMP socket (requires ping_group_range or CAP_NET_RAW): %w", err)
+	}
+	return conn, false, nil
+}
+
 func (p *PingDriver) GetEndpoint() string {
 	return p.endpoint
 }
@@ -0,0 +1,49 @@
+package driver
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/LiciousTech/endpoint-monitoring-operator/api/v1alpha1"
+)
+
+func TestPingDriverLoopback(t *testing.T) {
+	d, err := NewPingDriver("127.0.0.1", &v1.PingCheck{
+		Count:    2,
+		Interval: &metav1.Duration{Duration: 10 * time.Millisecond},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	result, err := d.Check()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Error != nil && strings.Contains(result.Error.Error(), "ICMP socket") {
+		t.Skipf("ICMP sockets are not available: %v", result.Error)
+	}
+	if !result.Success {
+		t.Fatalf("expected loopback ping to succeed: %s", result.Message)
+	}
+	if result.Details["received"] != 2 || result.Details["packetLoss"] != 0.0 {
+		t.Errorf("unexpected ping details: %v", result.Details)
+	}
+}
+
+func TestPingDriverInvalidConfig(t *testing.T) {
+	loss := int32(150)
+	invalid := []*v1.PingCheck{
+		{MaxPacketLoss: &loss},
+		{PacketSize: 70000},
+		{IPVersion: "ipx"},
+	}
+	for _, check := range invalid {
+		if _, err := NewPingDriver("127.0.0.1", check); err == nil {
+			t.Errorf("expected error for config %+v", check)
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package driver
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"time"
+
+	// Registers the "postgres" database/sql driver
+	_ "github.com/lib/pq"
+
+	v1 "github.com/LiciousTech/endpoint-monitoring-operator/api/v1alpha1"
+)
+
+const (
+	defaultPostgresDatabase = "postgres"
+	defaultPostgresSSLMode  = "require"
+	defaultSQLQuery         = "SELECT 1"
+	defaultSQLTimeout       = 10 * time.Second
+)
+
+// Database roles reported by the SQL drivers
+const (
+	RolePrimary = "primary"
+	RoleStandby = "standby"
+)
+
+type PostgresDriver struct {
+	endpoint       string
+	dsn            string
+	query          string
+	expectedResult string
+	expectedRole   string
+	maxLag         time.Duration
+	timeout        time.Duration
+}
+
+func NewPostgresDriver(endpoint string, check *v1.PostgresCheck, creds *Credentials) (Driver, error) {
+	if endpoint == "" {
+		return nil, fmt.Errorf("endpoint cannot be empty")
+	}
+	if check == nil {
+		check = &v1.PostgresCheck{}
+	}
+
+	p := &PostgresDriver{
+		endpoint:       endpoint,
+		query:          check.Query,
+		expectedResult: check.ExpectedResult,
+		expectedRole:   check.ExpectedRole,
+		timeout:        defaultSQLTimeout,
+	}
+	if p.query == "" {
+		p.query = defaultSQLQuery
+	}
+	switch p.expectedRole {
+	case "", RolePrimary, RoleStandby:
+	default:
+		return nil, fmt.Errorf("unsupported expectedRole %q, expected primary or standby", p.expectedRole)
+	}
+	if check.MaxReplicationLag != nil {
+		p.maxLag = check.MaxReplicationLag.Duration
+	}
+	if check.ConnectTimeout != nil && check.ConnectTimeout.Duration > 0 {
+		p.timeout = check.ConnectTimeout.Duration
+	}
+
+	sslMode := check.SSLMode
+	if sslMode == "" {
+		sslMode = defaultPostgresSSLMode
+	}
+	switch sslMode {
+	case "disable", "require", "verify-ca", "verify-full":
+	default:
+		return nil, fmt.Errorf("unsupported sslMode %q", sslMode)
+	}
+
+	host := endpoint
+	if _, _, err := net.SplitHostPort(endpoint); err != nil {
+		host = net.JoinHostPort(endpoint, "5432")
+	}
+	database := check.Database
+	if database == "" {
+		database = defaultPostgresDatabase
+	}
+
+	dsn := url.URL{
+		Scheme: "postgres",
+		Host:   host,
+		Path:   "/" + database,
+		RawQuery: url.Values{
+			"sslmode":          {sslMode},
+			"connect_timeout":  {strconv.Itoa(max(1, int(p.timeout.Seconds())))},
+			"application_name": {"endpoint-monitoring-operator"},
+		}.Encode(),
+	}
+	if creds != nil && creds.Username != "" {
+		dsn.User = url.UserPassword(creds.Username, creds.Password)
+	}
+	p.dsn = dsn.String()
+
+	return p, nil
+}
+
+func (p *PostgresDriver) Check() (*CheckResult, error) {
+	start := time.Now()
+	result := &CheckResult{}
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+	defer cancel()
+
+	db, err := sql.Open("postgres", p.dsn)
+	if err != nil {
+		result.ResponseTime = time.Since(start)
+		result.Success = false
+		result.Error = err
+		result.Message = fmt.Sprintf("PostgreSQL check failed: %v", err)
+		return result, nil
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	value, err := queryScalar(ctx, db, p.query)
+	duration := time.Since(start)
+	result.ResponseTime = duration
+	if err != nil {
+		result.Success = false
+		result.Error = err
+		result.Message = fmt.Sprintf("PostgreSQL check failed (response time: %v): %v", duration, err)
+		return result, nil
+	}
+
+	var inRecovery bool
+	if err := db.QueryRowContext(ctx, "SELECT pg_is_in_recovery()").Scan(&inRecovery); err != nil {
+		result.Success = false
+		result.Error = err
+		result.Message = fmt.Sprintf("PostgreSQL check failed to determine replication role: %v", err)
+		return result, nil
+	}
+
+	role := RolePrimary
+	var lag time.Duration
+	if inRecovery {
+		role = RoleStandby
+		var lagSeconds float64
+		// A standby that has replayed everything it received is not lagging, even if the primary is idle
+		lagQuery := `SELECT CASE WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+			ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0) END`
+		if err := db.QueryRowContext(ctx, lagQuery).Scan(&lagSeconds); err != nil {
+			result.Success = false
+			result.Error = err
+			result.Message = fmt.Sprintf("PostgreSQL check failed to determine replication lag: %v", err)
+			return result, nil
+		}
+		lag = time.Duration(lagSeconds * float64(time.Second))
+	}
+
+	result.Details = map[string]interface{}{
+		"result":         value,
+		"role":           role,
+		"replicationLag": lag.Seconds(),
+	}
+	summary := fmt.Sprintf("role: %s, result: %q", role, value)
+	if role == RoleStandby {
+		summary += fmt.Sprintf(", replication lag: %v", lag)
+	}
+
+	switch {
+	case p.expectedResult != "" && value != p.expectedResult:
+		result.Success = false
+		result.Message = fmt.Sprintf("PostgreSQL check failed, query returned %q, expected %q (%s, response time: %v)",
+			value, p.expectedResult, summary, duration)
+	case p.expectedRole != "" && role != p.expectedRole:
+		result.Success = false
+		result.Message = fmt.Sprintf("PostgreSQL check failed, server is %s, expected %s (%s, response time: %v)",
+			role, p.expectedRole, summary, duration)
+	case role == RoleStandby && p.maxLag > 0 && lag > p.maxLag:
+		result.Success = false
+		result.Message = fmt.Sprintf("PostgreSQL check failed, replication lag %v exceeds %v (%s, response time: %v)",
+			lag, p.maxLag, summary, duration)
+	default:
+		result.Success = true
+		result.Message = fmt.Sprintf("PostgreSQL check successful (%s, response time: %v)", summary, duration)
+	}
+
+	return result, nil
+}
+
+// queryScalar runs query and returns the first column of the first row as text
+func queryScalar(ctx context.Context, db *sql.DB, query string) (string, error) {
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return "", err
+	}
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return "", err
+		}
+		return "", fmt.Errorf("query returned no rows")
+	}
+
+	values := make([]sql.NullString, len(columns))
+	targets := make([]interface{}, len(columns))
+	for i := range values {
+		targets[i] = &values[i]
+	}
+	if err := rows.Scan(targets...); err != nil {
+		return "", err
+	}
+	if len(values) == 0 || !values[0].Valid {
+		return "NULL", nil
+	}
+	return values[0].String, nil
+}
+
+func (p *PostgresDriver) GetEndpoint() string {
+	return p.endpoint
+}
+
+func (p *PostgresDriver) GetType() string {
+	return "postgres"
+}
@@ -0,0 +1,237 @@
+package driver
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/LiciousTech/endpoint-monitoring-operator/api/v1alpha1"
+)
+
+// fakePostgres is an in-process stand-in speaking just enough of the PostgreSQL
+// wire protocol (cleartext auth and simple queries) to exercise the driver.
+type fakePostgres struct {
+	password   string
+	inRecovery bool
+	lagSeconds string
+	// results maps a query to the text value of its single result column
+	results map[string]string
+}
+
+func (f *fakePostgres) start(t *testing.T) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go f.serve(conn)
+		}
+	}()
+	return ln.Addr().String()
+}
+
+func (f *fakePostgres) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+
+	// Startup message: length-prefixed, no type byte
+	var length int32
+	if err := binary.Read(r, binary.BigEndian, &length); err != nil {
+		return
+	}
+	if _, err := io.CopyN(io.Discard, r, int64(length-4)); err != nil {
+		return
+	}
+
+	writeMessage(conn, 'R', int32Bytes(3)) // cleartext password
+	typ, body, err := readMessage(r)
+	if err != nil || typ != 'p' {
+		return
+	}
+	if strings.TrimRight(string(body), "\x00") != f.password {
+		writeMessage(conn, 'E', []byte("SFATAL\x00C28P01\x00Mpassword authentication failed\x00\x00"))
+		return
+	}
+	writeMessage(conn, 'R', int32Bytes(0))
+	writeMessage(conn, 'Z', []byte("I"))
+
+	for {
+		typ, body, err := readMessage(r)
+		if err != nil || typ == 'X' {
+			return
+		}
+		if typ != 'Q' {
+			continue
+		}
+		query := strings.TrimRight(string(body), "\x00")
+
+		var value string
+		var oid int32 = 25 // text
+		switch {
+		case query == "SELECT pg_is_in_recovery()":
+			value, oid = "f", 16
+			if f.inRecovery {
+				value = "t"
+			}
+		case strings.Contains(query, "pg_last_wal_replay_lsn"):
+			value, oid = f.lagSeconds, 701
+		default:
+			var ok bool
+			if value, ok = f.results[query]; !ok {
+				writeMessage(conn, 'E', []byte("SERROR\x00C42P01\x00Mrelation does not exist\x00\x00"))
+				writeMessage(conn, 'Z', []byte("I"))
+				continue
+			}
+		}
+
+		row := []byte{0, 1}
+		row = append(row, []byte("value\x00")...)
+		row = append(row, int32Bytes(0)...)
+		row = append(row, 0, 0)
+		row = append(row, int32Bytes(oid)...)
+		row = append(row, 0xff, 0xff)
+		row = append(row, int32Bytes(-1)...)
+		row = append(row, 0, 0)
+		writeMessage(conn, 'T', row)
+
+		data := []byte{0, 1}
+		data = append(data, int32Bytes(int32(len(value)))...)
+		data = append(data, value...)
+		writeMessage(conn, 'D', data)
+		writeMessage(conn, 'C', []byte("SELECT 1\x00"))
+		writeMessage(conn, 'Z', []byte("I"))
+	}
+}
+
+func readMessage(r *bufio.Reader) (byte, []byte, error) {
+	typ, err := r.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+	var length int32
+	if err := binary.Read(r, binary.BigEndian, &length); err != nil {
+		return 0, nil, err
+	}
+	body := make([]byte, length-4)
+	_, err = io.ReadFull(r, body)
+	return typ, body, err
+}
+
+func writeMessage(w io.Writer, typ byte, body []byte) {
+	msg := append([]byte{typ}, int32Bytes(int32(len(body)+4))...)
+	_, _ = w.Write(append(msg, body...))
+}
+
+func int32Bytes(v int32) []byte {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, uint32(v))
+	return b
+}
+
+func TestPostgresDriver(t *testing.T) {
+	primary := (&fakePostgres{
+		password: "s3cret",
+		results:  map[string]string{"SELECT 1": "1", "SELECT count(*) FROM orders": "42"},
+	}).start(t)
+	standby := (&fakePostgres{
+		password:   "s3cret",
+		inRecovery: true,
+		lagSeconds: "45.5",
+		results:    map[string]string{"SELECT 1": "1"},
+	}).start(t)
+
+	creds := &Credentials{Username: "monitor", Password: "s3cret"}
+	maxLag := &metav1.Duration{Duration: 30 * time.Second}
+
+	tests := []struct {
+		name      string
+		endpoint  string
+		check     v1.PostgresCheck
+		creds     *Credentials
+		success   bool
+		contained string
+	}{
+		{
+			name:      "default probe query",
+			endpoint:  primary,
+			creds:     creds,
+			success:   true,
+			contained: "role: primary",
+		},
+		{
+			name:     "scalar assertion",
+			endpoint: primary,
+			check:    v1.PostgresCheck{Query: "SELECT count(*) FROM orders", ExpectedResult: "42"},
+			creds:    creds,
+			success:  true,
+		},
+		{
+			name:      "scalar mismatch",
+			endpoint:  primary,
+			check:     v1.PostgresCheck{Query: "SELECT count(*) FROM orders", ExpectedResult: "0"},
+			creds:     creds,
+			contained: `query returned "42", expected "0"`,
+		},
+		{
+			name:      "query error",
+			endpoint:  primary,
+			check:     v1.PostgresCheck{Query: "SELECT * FROM missing"},
+			creds:     creds,
+			contained: "relation does not exist",
+		},
+		{
+			name:      "login rejected",
+			endpoint:  primary,
+			creds:     &Credentials{Username: "monitor", Password: "wrong"},
+			contained: "password authentication failed",
+		},
+		{
+			name:      "unexpected role",
+			endpoint:  standby,
+			check:     v1.PostgresCheck{ExpectedRole: "primary"},
+			creds:     creds,
+			contained: "server is standby, expected primary",
+		},
+		{
+			name:      "replication lag",
+			endpoint:  standby,
+			check:     v1.PostgresCheck{ExpectedRole: "standby", MaxReplicationLag: maxLag},
+			creds:     creds,
+			contained: "replication lag 45.5s exceeds 30s",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.check.SSLMode = "disable"
+			d, err := NewPostgresDriver(tt.endpoint, &tt.check, tt.creds)
+			if err != nil {
+				t.Fatalf("unexpected error creating driver: %v", err)
+			}
+			result, err := d.Check()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Success != tt.success {
+				t.Fatalf("expected success=%t, got %t: %s", tt.success, result.Success, result.Message)
+			}
+			if tt.contained != "" && !strings.Contains(result.Message, tt.contained) {
+				t.Errorf("expected message to contain %q, got: %s", tt.contained, result.Message)
+			}
+		})
+	}
+}
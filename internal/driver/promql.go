@@ -0,0 +1,285 @@
+package driver
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "github.com/LiciousTech/endpoint-monitoring-operator/api/v1alpha1"
+)
+
+const defaultPromQLTimeout = 30 * time.Second
+
+type PromQLDriver struct {
+	endpoint    string
+	client      *http.Client
+	query       string
+	operator    string
+	threshold   float64
+	failOnEmpty bool
+	creds       *Credentials
+	bearerToken string
+}
+
+// promQueryResponse is the envelope returned by /api/v1/query
+type promQueryResponse struct {
+	Status    string `json:"status"`
+	ErrorType string `json:"errorType"`
+	Error     string `json:"error"`
+	Data      struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+// promSample is one series of an instant query result
+type promSample struct {
+	Metric map[string]string `json:"metric"`
+	Value  [2]interface{}    `json:"value"`
+}
+
+func NewPromQLDriver(endpoint string, check *v1.PromqlCheck, creds *Credentials, bearerToken string) (Driver, error) {
+	if endpoint == "" {
+		return nil, fmt.Errorf("endpoint cannot be empty")
+	}
+	if check == nil || check.Query == "" {
+		return nil, fmt.Errorf("promqlCheck.query is required")
+	}
+	if creds != nil && bearerToken != "" {
+		return nil, fmt.Errorf("credentialsSecretRef and bearerTokenSecretRef are mutually exclusive")
+	}
+
+	p := &PromQLDriver{
+		endpoint:    strings.TrimSuffix(endpoint, "/"),
+		query:       check.Query,
+		operator:    check.Operator,
+		failOnEmpty: check.FailOnEmpty,
+		creds:       creds,
+		bearerToken: bearerToken,
+	}
+
+	switch p.operator {
+	case "":
+		if check.Threshold != "" {
+			return nil, fmt.Errorf("threshold requires an operator")
+		}
+	case OperatorGt, OperatorLt, OperatorGte, OperatorLte, OperatorEq, OperatorNe:
+		threshold, err := strconv.ParseFloat(check.Threshold, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid threshold %q: %w", check.Threshold, err)
+		}
+		p.threshold = threshold
+	default:
+		return nil, fmt.Errorf("unsupported operator %q, expected gt, lt, gte, lte, eq or ne", p.operator)
+	}
+
+	timeout := defaultPromQLTimeout
+	if check.Timeout != nil && check.Timeout.Duration > 0 {
+		timeout = check.Timeout.Duration
+	}
+	p.client = &http.Client{Timeout: timeout}
+
+	return p, nil
+}
+
+func (p *PromQLDriver) Check() (*CheckResult, error) {
+	start := time.Now()
+	result := &CheckResult{}
+
+	form := url.Values{"query": {p.query}}
+	req, err := http.NewRequest(http.MethodPost, p.endpoint+"/api/v1/query", strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	switch {
+	case p.bearerToken != "":
+		req.Header.Set("Authorization", "Bearer "+p.bearerToken)
+	case p.creds != nil:
+		req.SetBasicAuth(p.creds.Username, p.creds.Password)
+	}
+
+	resp, err := p.client.Do(req)
+	result.ResponseTime = time.Since(start)
+	if err != nil {
+		result.Success = false
+		result.Error = err
+		result.Message = fmt.Sprintf("PromQL check failed: %v", err)
+		return result, nil
+	}
+	defer resp.Body.Close()
+	result.StatusCode = resp.StatusCode
+
+	// Query errors come back as 400/422 with the reason in the JSON envelope
+	var body promQueryResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		result.Success = false
+		if resp.StatusCode != http.StatusOK {
+			result.Message = fmt.Sprintf("PromQL check failed (status: %d, response time: %v)", resp.StatusCode, result.ResponseTime)
+			return result, nil
+		}
+		result.Error = err
+		result.Message = fmt.Sprintf("PromQL check failed to parse response: %v", err)
+		return result, nil
+	}
+	if body.Status != "success" {
+		result.Success = false
+		result.Message = fmt.Sprintf("PromQL check failed, query error (status: %d, %s): %s", resp.StatusCode, body.ErrorType, body.Error)
+		return result, nil
+	}
+
+	samples, err := decodePromResult(body.Data.ResultType, body.Data.Result)
+	if err != nil {
+		result.Success = false
+		result.Error = err
+		result.Message = fmt.Sprintf("PromQL check failed: %v", err)
+		return result, nil
+	}
+
+	values := make(map[string]float64, len(samples))
+	for _, s := range samples {
+		values[s.series] = s.value
+	}
+	result.Details = map[string]interface{}{
+		"resultType": body.Data.ResultType,
+		"samples":    values,
+	}
+
+	if len(samples) == 0 {
+		result.Success = !p.failOnEmpty
+		if p.failOnEmpty {
+			result.Message = fmt.Sprintf("PromQL check failed, query returned no samples (response time: %v)", result.ResponseTime)
+		} else {
+			result.Message = fmt.Sprintf("PromQL check successful, query returned no samples (response time: %v)", result.ResponseTime)
+		}
+		return result, nil
+	}
+
+	if p.operator == "" {
+		result.Success = true
+		result.Message = fmt.Sprintf("PromQL check successful (%d sample(s), first: %s = %g, response time: %v)",
+			len(samples), samples[0].series, samples[0].value, result.ResponseTime)
+		return result, nil
+	}
+
+	var violations []string
+	for _, s := range samples {
+		if !compareFloat(s.value, p.operator, p.threshold) {
+			violations = append(violations, fmt.Sprintf("%s = %g", s.series, s.value))
+		}
+	}
+	if len(violations) > 0 {
+		result.Success = false
+		result.Message = fmt.Sprintf("PromQL check failed, %d of %d sample(s) not %s %g: %s (response time: %v)",
+			len(violations), len(samples), p.operator, p.threshold, listPreview(violations), result.ResponseTime)
+		return result, nil
+	}
+
+	result.Success = true
+	result.Message = fmt.Sprintf("PromQL check successful (%d sample(s) %s %g, response time: %v)",
+		len(samples), p.operator, p.threshold, result.ResponseTime)
+	return result, nil
+}
+
+type promValue struct {
+	series string
+	value  float64
+}
+
+// decodePromResult flattens vector and scalar results into series/value pairs ordered by series
+func decodePromResult(resultType string, raw json.RawMessage) ([]promValue, error) {
+	switch resultType {
+	case "vector":
+		var samples []promSample
+		if err := json.Unmarshal(raw, &samples); err != nil {
+			return nil, fmt.Errorf("failed to parse vector result: %w", err)
+		}
+		values := make([]promValue, 0, len(samples))
+		for _, s := range samples {
+			v, err := parsePromValue(s.Value)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, promValue{series: formatSeries(s.Metric), value: v})
+		}
+		sort.Slice(values, func(i, j int) bool { return values[i].series < values[j].series })
+		return values, nil
+	case "scalar":
+		var pair [2]interface{}
+		if err := json.Unmarshal(raw, &pair); err != nil {
+			return nil, fmt.Errorf("failed to parse scalar result: %w", err)
+		}
+		v, err := parsePromValue(pair)
+		if err != nil {
+			return nil, err
+		}
+		return []promValue{{series: "scalar", value: v}}, nil
+	default:
+		return nil, fmt.Errorf("unsupported result type %q, expected vector or scalar", resultType)
+	}
+}
+
+// parsePromValue reads the string value of a [timestamp, "value"] pair
+func parsePromValue(pair [2]interface{}) (float64, error) {
+	s, ok := pair[1].(string)
+	if !ok {
+		return 0, fmt.Errorf("unexpected sample value %v", pair[1])
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid sample value %q: %w", s, err)
+	}
+	return v, nil
+}
+
+// formatSeries renders labels in the familiar {name="value"} notation
+func formatSeries(metric map[string]string) string {
+	names := make([]string, 0, len(metric))
+	for name := range metric {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	labels := make([]string, 0, len(names))
+	for _, name := range names {
+		labels = append(labels, fmt.Sprintf("%s=%q", name, metric[name]))
+	}
+	return "{" + strings.Join(labels, ", ") + "}"
+}
+
+// compareFloat reports whether "actual <op> expected" holds; NaN never satisfies a comparison
+func compareFloat(actual float64, op string, expected float64) bool {
+	if math.IsNaN(actual) {
+		return false
+	}
+	switch op {
+	case OperatorGt:
+		return actual > expected
+	case OperatorLt:
+		return actual < expected
+	case OperatorGte:
+		return actual >= expected
+	case OperatorLte:
+		return actual <= expected
+	case OperatorEq:
+		return actual == expected
+	case OperatorNe:
+		return actual != expected
+	default:
+		return false
+	}
+}
+
+func (p *PromQLDriver) GetEndpoint() string {
+	return p.endpoint
+}
+
+func (p *PromQLDriver) GetType() string {
+	return "promql"
+}
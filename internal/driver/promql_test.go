@@ -0,0 +1,119 @@
+package driver
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	v1 "github.com/LiciousTech/endpoint-monitoring-operator/api/v1alpha1"
+)
+
+// startPrometheusServer answers a few canned instant queries the way the Prometheus HTTP API does
+func startPrometheusServer(t *testing.T) string {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/query" || r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer t0ken" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.FormValue("query") {
+		case "error_ratio":
+			fmt.Fprint(w, `{"status":"success","data":{"resultType":"vector","result":[
+				{"metric":{"job":"api"},"value":[1700000000,"0.01"]},
+				{"metric":{"job":"checkout"},"value":[1700000000,"0.12"]}]}}`)
+		case "scalar(1)":
+			fmt.Fprint(w, `{"status":"success","data":{"resultType":"scalar","result":[1700000000,"1"]}}`)
+		case "absent_metric":
+			fmt.Fprint(w, `{"status":"success","data":{"resultType":"vector","result":[]}}`)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"status":"error","errorType":"bad_data","error":"1:5: parse error: unexpected end of input"}`)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func TestPromQLDriver(t *testing.T) {
+	endpoint := startPrometheusServer(t)
+
+	tests := []struct {
+		name      string
+		check     *v1.PromqlCheck
+		token     string
+		success   bool
+		contained string
+	}{
+		{
+			name:      "all samples below threshold",
+			check:     &v1.PromqlCheck{Query: "error_ratio", Operator: "lt", Threshold: "0.5"},
+			success:   true,
+			contained: "2 sample(s) lt 0.5",
+		},
+		{
+			name:      "one sample over threshold",
+			check:     &v1.PromqlCheck{Query: "error_ratio", Operator: "lt", Threshold: "0.05"},
+			contained: `1 of 2 sample(s) not lt 0.05: {job="checkout"} = 0.12`,
+		},
+		{
+			name:    "scalar result",
+			check:   &v1.PromqlCheck{Query: "scalar(1)", Operator: "eq", Threshold: "1"},
+			success: true,
+		},
+		{
+			name:      "empty result passes by default",
+			check:     &v1.PromqlCheck{Query: "absent_metric", Operator: "gt", Threshold: "0"},
+			success:   true,
+			contained: "no samples",
+		},
+		{
+			name:      "empty result fails when configured",
+			check:     &v1.PromqlCheck{Query: "absent_metric", FailOnEmpty: true},
+			contained: "query returned no samples",
+		},
+		{
+			name:      "query error",
+			check:     &v1.PromqlCheck{Query: "sum("},
+			contained: "bad_data): 1:5: parse error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := NewPromQLDriver(endpoint, tt.check, nil, "t0ken")
+			if err != nil {
+				t.Fatalf("unexpected error creating driver: %v", err)
+			}
+			result, err := d.Check()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Success != tt.success {
+				t.Fatalf("expected success=%t, got %t: %s", tt.success, result.Success, result.Message)
+			}
+			if tt.contained != "" && !strings.Contains(result.Message, tt.contained) {
+				t.Errorf("expected message to contain %q, got: %s", tt.contained, result.Message)
+			}
+		})
+	}
+
+	d, err := NewPromQLDriver(endpoint, &v1.PromqlCheck{Query: "error_ratio"}, nil, "")
+	if err != nil {
+		t.Fatalf("unexpected error creating driver: %v", err)
+	}
+	if result, _ := d.Check(); result.Success || !strings.Contains(result.Message, "status: 401") {
+		t.Errorf("expected unauthenticated query to fail with 401, got: %s", result.Message)
+	}
+
+	if _, err := NewPromQLDriver(endpoint, &v1.PromqlCheck{Query: "up", Operator: "lt", Threshold: "low"}, nil, ""); err == nil {
+		t.Error("expected a non-numeric threshold to be rejected")
+	}
+}
@@ -0,0 +1,305 @@
+package driver
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "github.com/LiciousTech/endpoint-monitoring-operator/api/v1alpha1"
+)
+
+const (
+	defaultRedisTimeout = 5 * time.Second
+
+	RedisModeStandalone = "standalone"
+	RedisModeSentinel   = "sentinel"
+	RedisModeCluster    = "cluster"
+
+	redisRoleMaster  = "master"
+	redisRoleReplica = "replica"
+)
+
+type RedisDriver struct {
+	endpoint          string
+	address           string
+	mode              string
+	masterName        string
+	creds             *Credentials
+	tls               *v1.TLSConfig
+	expectedRole      string
+	requireMasterLink bool
+	maxMemoryPercent  float64
+	maxUsedMemory     int64
+	timeout           time.Duration
+}
+
+func NewRedisDriver(endpoint string, check *v1.RedisCheck, creds *Credentials) (Driver, error) {
+	if endpoint == "" {
+		return nil, fmt.Errorf("endpoint cannot be empty")
+	}
+	if check == nil {
+		check = &v1.RedisCheck{}
+	}
+
+	r := &RedisDriver{
+		endpoint:          endpoint,
+		address:           endpoint,
+		mode:              check.Mode,
+		masterName:        check.MasterName,
+		creds:             creds,
+		tls:               check.TLS,
+		expectedRole:      check.ExpectedRole,
+		requireMasterLink: check.RequireMasterLink,
+		timeout:           defaultRedisTimeout,
+	}
+	if _, _, err := net.SplitHostPort(endpoint); err != nil {
+		port := "6379"
+		if r.mode == RedisModeSentinel {
+			port = "26379"
+		}
+		r.address = net.JoinHostPort(endpoint, port)
+	}
+
+	switch r.mode {
+	case "":
+		r.mode = RedisModeStandalone
+	case RedisModeStandalone, RedisModeCluster:
+	case RedisModeSentinel:
+		if r.masterName == "" {
+			return nil, fmt.Errorf("masterName is required in sentinel mode")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported mode %q, expected standalone, sentinel or cluster", r.mode)
+	}
+	switch r.expectedRole {
+	case "", redisRoleMaster, redisRoleReplica:
+	default:
+		return nil, fmt.Errorf("unsupported expectedRole %q, expected master or replica", r.expectedRole)
+	}
+
+	if check.MaxMemoryPercent != nil {
+		if *check.MaxMemoryPercent <= 0 || *check.MaxMemoryPercent > 100 {
+			return nil, fmt.Errorf("maxMemoryPercent must be between 1 and 100")
+		}
+		r.maxMemoryPercent = float64(*check.MaxMemoryPercent)
+	}
+	if check.MaxUsedMemory != nil {
+		r.maxUsedMemory = check.MaxUsedMemory.Value()
+	}
+	if check.Timeout != nil && check.Timeout.Duration > 0 {
+		r.timeout = check.Timeout.Duration
+	}
+
+	return r, nil
+}
+
+func (r *RedisDriver) Check() (*CheckResult, error) {
+	start := time.Now()
+	result := &CheckResult{Details: map[string]interface{}{"mode": r.mode}}
+	fail := func(format string, args ...interface{}) (*CheckResult, error) {
+		result.ResponseTime = time.Since(start)
+		result.Success = false
+		result.Message = fmt.Sprintf("Redis check failed: "+format, args...)
+		return result, nil
+	}
+
+	target := r.address
+	expectedRole := r.expectedRole
+	var summary []string
+
+	if r.mode == RedisModeSentinel {
+		master, err := r.resolveMaster()
+		if err != nil {
+			result.Error = err
+			return fail("sentinel %s: %v", r.address, err)
+		}
+		target = master
+		if expectedRole == "" {
+			expectedRole = redisRoleMaster
+		}
+		result.Details["master"] = master
+		summary = append(summary, "master: "+master)
+	}
+
+	conn, err := r.connect(target)
+	if err != nil {
+		result.Error = err
+		return fail("%s: %v", target, err)
+	}
+	defer conn.Close()
+
+	pong, err := conn.doString("PING")
+	if err != nil {
+		result.Error = err
+		return fail("PING: %v", err)
+	}
+	if pong != "PONG" {
+		return fail("PING answered %q", pong)
+	}
+	result.ResponseTime = time.Since(start)
+
+	var failures []string
+
+	if expectedRole != "" || r.requireMasterLink {
+		info, err := conn.doString("INFO", "replication")
+		if err != nil {
+			result.Error = err
+			return fail("INFO replication: %v", err)
+		}
+		fields := parseInfo(info)
+		role := fields["role"]
+		if role == "slave" {
+			role = redisRoleReplica
+		}
+		result.Details["role"] = role
+		summary = append(summary, "role: "+role)
+		if expectedRole != "" && role != expectedRole {
+			failures = append(failures, fmt.Sprintf("role is %s, expected %s", role, expectedRole))
+		}
+		if role == redisRoleReplica {
+			link := fields["master_link_status"]
+			result.Details["masterLinkStatus"] = link
+			summary = append(summary, "master link: "+link)
+			if r.requireMasterLink && link != "up" {
+				failures = append(failures, fmt.Sprintf("master_link_status is %s", link))
+			}
+		} else {
+			result.Details["connectedReplicas"] = fields["connected_slaves"]
+		}
+	}
+
+	if r.maxMemoryPercent > 0 || r.maxUsedMemory > 0 {
+		info, err := conn.doString("INFO", "memory")
+		if err != nil {
+			result.Error = err
+			return fail("INFO memory: %v", err)
+		}
+		fields := parseInfo(info)
+		used, _ := strconv.ParseInt(fields["used_memory"], 10, 64)
+		limit, _ := strconv.ParseInt(fields["maxmemory"], 10, 64)
+		result.Details["usedMemory"] = used
+		result.Details["maxMemory"] = limit
+		summary = append(summary, "used memory: "+formatBytes(used))
+
+		if r.maxUsedMemory > 0 && used > r.maxUsedMemory {
+			failures = append(failures, fmt.Sprintf("used memory %s exceeds %s", formatBytes(used), formatBytes(r.maxUsedMemory)))
+		}
+		// Without maxmemory the instance may grow until the host runs out, so a percentage is meaningless
+		if r.maxMemoryPercent > 0 && limit > 0 {
+			percent := float64(used) / float64(limit) * 100
+			result.Details["memoryPercent"] = percent
+			if percent > r.maxMemoryPercent {
+				failures = append(failures, fmt.Sprintf("memory usage %.1f%% of maxmemory exceeds %.0f%%", percent, r.maxMemoryPercent))
+			}
+		}
+	}
+
+	if r.mode == RedisModeCluster {
+		info, err := conn.doString("CLUSTER", "INFO")
+		if err != nil {
+			result.Error = err
+			return fail("CLUSTER INFO: %v", err)
+		}
+		fields := parseInfo(info)
+		state := fields["cluster_state"]
+		result.Details["clusterState"] = state
+		result.Details["clusterSlotsOk"] = fields["cluster_slots_ok"]
+		result.Details["clusterKnownNodes"] = fields["cluster_known_nodes"]
+		summary = append(summary, fmt.Sprintf("cluster state: %s, slots ok: %s", state, fields["cluster_slots_ok"]))
+		if state != "ok" {
+			failures = append(failures, fmt.Sprintf("cluster_state is %s", state))
+		}
+	}
+
+	result.ResponseTime = time.Since(start)
+	summary = append(summary, fmt.Sprintf("response time: %v", result.ResponseTime))
+	if len(failures) > 0 {
+		result.Success = false
+		result.Message = fmt.Sprintf("Redis check failed, %s (%s)", strings.Join(failures, "; "), strings.Join(summary, ", "))
+		return result, nil
+	}
+
+	result.Success = true
+	result.Message = fmt.Sprintf("Redis check successful (%s)", strings.Join(summary, ", "))
+	return result, nil
+}
+
+// connect dials address and authenticates when credentials are configured
+func (r *RedisDriver) connect(address string) (*respConn, error) {
+	conn, err := dialRESP(address, r.timeout, buildTLSConfig(r.tls, address))
+	if err != nil {
+		return nil, err
+	}
+	if r.creds != nil && r.creds.Password != "" {
+		args := []string{"AUTH", r.creds.Password}
+		if r.creds.Username != "" {
+			args = []string{"AUTH", r.creds.Username, r.creds.Password}
+		}
+		if _, err := conn.do(args...); err != nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("AUTH: %w", err)
+		}
+	}
+	return conn, nil
+}
+
+// resolveMaster asks the sentinel for the current master address and rejects masters
+// the sentinel considers down
+func (r *RedisDriver) resolveMaster() (string, error) {
+	conn, err := r.connect(r.address)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	reply, err := conn.do("SENTINEL", "get-master-addr-by-name", r.masterName)
+	if err != nil {
+		return "", err
+	}
+	addr, ok := reply.([]interface{})
+	if !ok || len(addr) != 2 {
+		return "", fmt.Errorf("unknown master %q", r.masterName)
+	}
+	host, _ := addr[0].(string)
+	port, _ := addr[1].(string)
+
+	reply, err = conn.do("SENTINEL", "master", r.masterName)
+	if err != nil {
+		return "", err
+	}
+	state, _ := reply.([]interface{})
+	for i := 0; i+1 < len(state); i += 2 {
+		if key, _ := state[i].(string); key == "flags" {
+			flags, _ := state[i+1].(string)
+			if strings.Contains(flags, "s_down") || strings.Contains(flags, "o_down") {
+				return "", fmt.Errorf("master %s is flagged %s", r.masterName, flags)
+			}
+		}
+	}
+
+	return net.JoinHostPort(host, port), nil
+}
+
+// formatBytes renders a byte count using binary suffixes
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+func (r *RedisDriver) GetEndpoint() string {
+	return r.endpoint
+}
+
+func (r *RedisDriver) GetType() string {
+	return "redis"
+}
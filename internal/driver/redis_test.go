@@ -0,0 +1,263 @@
+package driver
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	v1 "github.com/LiciousTech/endpoint-monitoring-operator/api/v1alpha1"
+)
+
+// fakeRedis answers the handful of commands the driver sends. As a sentinel it
+// reports master as the address of the monitored master.
+type fakeRedis struct {
+	user, password string
+	info           map[string]string
+	clusterInfo    string
+	master         string
+	masterFlags    string
+}
+
+func (f *fakeRedis) start(t *testing.T) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go f.serve(conn)
+		}
+	}()
+	return ln.Addr().String()
+}
+
+func (f *fakeRedis) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	authenticated := f.password == ""
+
+	bulk := func(s string) string { return fmt.Sprintf("$%d\r\n%s\r\n", len(s), s) }
+
+	for {
+		args, err := readRESPCommand(r)
+		if err != nil {
+			return
+		}
+		command := strings.ToUpper(strings.Join(args, " "))
+
+		var reply string
+		switch {
+		case args[0] == "AUTH":
+			user, password := "default", args[len(args)-1]
+			if len(args) == 3 {
+				user = args[1]
+			}
+			if password == f.password && (f.user == "" || user == f.user) {
+				authenticated = true
+				reply = "+OK\r\n"
+			} else {
+				reply = "-WRONGPASS invalid username-password pair or user is disabled.\r\n"
+			}
+		case !authenticated:
+			reply = "-NOAUTH Authentication required.\r\n"
+		case command == "PING":
+			reply = "+PONG\r\n"
+		case strings.HasPrefix(command, "INFO "):
+			section := strings.ToLower(args[1])
+			reply = bulk("# " + section + "\r\n" + f.info[section])
+		case command == "CLUSTER INFO":
+			if f.clusterInfo == "" {
+				reply = "-ERR This instance has cluster support disabled\r\n"
+			} else {
+				reply = bulk(f.clusterInfo)
+			}
+		case strings.HasPrefix(command, "SENTINEL GET-MASTER-ADDR-BY-NAME"):
+			if args[2] != "mymaster" {
+				reply = "*-1\r\n"
+				break
+			}
+			host, port, _ := net.SplitHostPort(f.master)
+			reply = "*2\r\n" + bulk(host) + bulk(port)
+		case strings.HasPrefix(command, "SENTINEL MASTER "):
+			reply = "*4\r\n" + bulk("name") + bulk("mymaster") + bulk("flags") + bulk(f.masterFlags)
+		default:
+			reply = "-ERR unknown command\r\n"
+		}
+		if _, err := conn.Write([]byte(reply)); err != nil {
+			return
+		}
+	}
+}
+
+func readRESPCommand(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+	if err != nil {
+		return nil, err
+	}
+	args := make([]string, n)
+	for i := range args {
+		if _, err := r.ReadString('\n'); err != nil {
+			return nil, err
+		}
+		arg, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		args[i] = strings.TrimSuffix(arg, "\r\n")
+	}
+	return args, nil
+}
+
+func TestRedisDriver(t *testing.T) {
+	master := (&fakeRedis{
+		user:     "monitor",
+		password: "s3cret",
+		info: map[string]string{
+			"replication": "role:master\r\nconnected_slaves:1\r\n",
+			"memory":      "used_memory:943718400\r\nmaxmemory:1073741824\r\n",
+		},
+	}).start(t)
+	brokenReplica := (&fakeRedis{
+		info: map[string]string{"replication": "role:slave\r\nmaster_host:10.0.0.1\r\nmaster_link_status:down\r\n"},
+	}).start(t)
+	cluster := (&fakeRedis{
+		clusterInfo: "cluster_state:fail\r\ncluster_slots_assigned:16384\r\ncluster_slots_ok:10923\r\ncluster_known_nodes:6\r\n",
+	}).start(t)
+	sentinel := (&fakeRedis{user: "monitor", password: "s3cret", master: master}).start(t)
+	downSentinel := (&fakeRedis{master: master, masterFlags: "master,s_down,o_down"}).start(t)
+
+	creds := &Credentials{Username: "monitor", Password: "s3cret"}
+	percent := int32(80)
+	limit := resource.MustParse("1Gi")
+
+	tests := []struct {
+		name      string
+		endpoint  string
+		check     *v1.RedisCheck
+		creds     *Credentials
+		success   bool
+		contained string
+	}{
+		{
+			name:     "ping with ACL user",
+			endpoint: master,
+			creds:    creds,
+			success:  true,
+		},
+		{
+			name:      "wrong password",
+			endpoint:  master,
+			creds:     &Credentials{Username: "monitor", Password: "wrong"},
+			contained: "WRONGPASS",
+		},
+		{
+			name:      "missing password",
+			endpoint:  master,
+			contained: "NOAUTH",
+		},
+		{
+			name:      "expected role",
+			endpoint:  master,
+			check:     &v1.RedisCheck{ExpectedRole: "master", MaxUsedMemory: &limit},
+			creds:     creds,
+			success:   true,
+			contained: "role: master, used memory: 900.0MiB",
+		},
+		{
+			name:      "memory percentage",
+			endpoint:  master,
+			check:     &v1.RedisCheck{MaxMemoryPercent: &percent},
+			creds:     creds,
+			contained: "memory usage 87.9% of maxmemory exceeds 80%",
+		},
+		{
+			name:      "replica with broken link",
+			endpoint:  brokenReplica,
+			check:     &v1.RedisCheck{ExpectedRole: "replica", RequireMasterLink: true},
+			contained: "master_link_status is down",
+		},
+		{
+			name:      "cluster state",
+			endpoint:  cluster,
+			check:     &v1.RedisCheck{Mode: "cluster"},
+			contained: "cluster_state is fail",
+		},
+		{
+			name:      "sentinel resolves master",
+			endpoint:  sentinel,
+			check:     &v1.RedisCheck{Mode: "sentinel", MasterName: "mymaster"},
+			creds:     creds,
+			success:   true,
+			contained: "master: " + master + ", role: master",
+		},
+		{
+			name:      "sentinel unknown master",
+			endpoint:  sentinel,
+			check:     &v1.RedisCheck{Mode: "sentinel", MasterName: "other"},
+			creds:     creds,
+			contained: `unknown master "other"`,
+		},
+		{
+			name:      "sentinel reports master down",
+			endpoint:  downSentinel,
+			check:     &v1.RedisCheck{Mode: "sentinel", MasterName: "mymaster"},
+			contained: "flagged master,s_down,o_down",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := NewRedisDriver(tt.endpoint, tt.check, tt.creds)
+			if err != nil {
+				t.Fatalf("unexpected error creating driver: %v", err)
+			}
+			result, err := d.Check()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Success != tt.success {
+				t.Fatalf("expected success=%t, got %t: %s", tt.success, result.Success, result.Message)
+			}
+			if tt.contained != "" && !strings.Contains(result.Message, tt.contained) {
+				t.Errorf("expected message to contain %q, got: %s", tt.contained, result.Message)
+			}
+		})
+	}
+}
+
+func TestRESPReplyLimits(t *testing.T) {
+	tests := []struct {
+		name      string
+		reply     string
+		contained string
+	}{
+		{name: "oversized bulk", reply: "$999999999\r\n", contained: "bulk reply of 999999999 bytes"},
+		{name: "oversized array", reply: "*999999999\r\n", contained: "array reply of 999999999 items"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &respConn{reader: bufio.NewReader(strings.NewReader(tt.reply))}
+			_, err := c.readReply()
+			if err == nil || !strings.Contains(err.Error(), tt.contained) {
+				t.Fatalf("expected error containing %q, got %v", tt.contained, err)
+			}
+		})
+	}
+}
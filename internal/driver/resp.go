@@ -0,0 +1,166 @@
+package driver
+
+import (
+	"bufio"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Replies are sized by the server, so their lengths are capped before anything is allocated.
+// INFO, the largest reply the driver reads, is a few kilobytes.
+const (
+	maxBulkLength  = 1 << 20
+	maxArrayLength = 1 << 16
+)
+
+// respError is an error reply sent by a Redis server
+type respError string
+
+func (e respError) Error() string {
+	return string(e)
+}
+
+// respConn is a minimal RESP2 client connection used by the Redis driver
+type respConn struct {
+	conn    net.Conn
+	reader  *bufio.Reader
+	timeout time.Duration
+}
+
+func dialRESP(address string, timeout time.Duration, tlsConfig *tls.Config) (*respConn, error) {
+	conn, err := net.DialTimeout("tcp", address, timeout)
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig != nil {
+		tlsConn := tls.Client(conn, tlsConfig)
+		if err := tlsConn.SetDeadline(time.Now().Add(timeout)); err == nil {
+			err = tlsConn.Handshake()
+		}
+		if err != nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("TLS handshake failed: %w", err)
+		}
+		conn = tlsConn
+	}
+	return &respConn{conn: conn, reader: bufio.NewReader(conn), timeout: timeout}, nil
+}
+
+func (c *respConn) Close() error {
+	return c.conn.Close()
+}
+
+// do sends a command and returns its reply: string, int64, []interface{} or nil.
+// Error replies are returned as respError.
+func (c *respConn) do(args ...string) (interface{}, error) {
+	if err := c.conn.SetDeadline(time.Now().Add(c.timeout)); err != nil {
+		return nil, err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if _, err := c.conn.Write([]byte(b.String())); err != nil {
+		return nil, err
+	}
+
+	reply, err := c.readReply()
+	if err != nil {
+		return nil, err
+	}
+	if e, ok := reply.(respError); ok {
+		return nil, e
+	}
+	return reply, nil
+}
+
+// doString is do for commands answering with a simple or bulk string
+func (c *respConn) doString(args ...string) (string, error) {
+	reply, err := c.do(args...)
+	if err != nil {
+		return "", err
+	}
+	s, ok := reply.(string)
+	if !ok {
+		return "", fmt.Errorf("unexpected reply to %s: %v", args[0], reply)
+	}
+	return s, nil
+}
+
+func (c *respConn) readReply() (interface{}, error) {
+	line, err := c.reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return nil, fmt.Errorf("malformed reply")
+	}
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return respError(line[1:]), nil
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, fmt.Errorf("malformed bulk length %q", line)
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		if n > maxBulkLength {
+			return nil, fmt.Errorf("bulk reply of %d bytes exceeds the %d byte limit", n, maxBulkLength)
+		}
+		buf := make([]byte, n+2)
+		if _, err := io.ReadFull(c.reader, buf); err != nil {
+			return nil, err
+		}
+		return string(buf[:n]), nil
+	case '*':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, fmt.Errorf("malformed array length %q", line)
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		if n > maxArrayLength {
+			return nil, fmt.Errorf("array reply of %d items exceeds the %d item limit", n, maxArrayLength)
+		}
+		items := make([]interface{}, n)
+		for i := range items {
+			if items[i], err = c.readReply(); err != nil {
+				return nil, err
+			}
+		}
+		return items, nil
+	default:
+		return nil, fmt.Errorf("unsupported reply type %q", line[0])
+	}
+}
+
+// parseInfo turns the output of INFO or CLUSTER INFO into a field map
+func parseInfo(info string) map[string]string {
+	fields := make(map[string]string)
+	for _, line := range strings.Split(info, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if key, value, ok := strings.Cut(line, ":"); ok {
+			fields[key] = value
+		}
+	}
+	return fields
+}
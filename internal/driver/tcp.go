@@ -1,34 +1,89 @@
 package driver
 
 import (
+	"bytes"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
 	"net"
+	"regexp"
 	"time"
+
+	v1 "github.com/LiciousTech/endpoint-monitoring-operator/api/v1alpha1"
+)
+
+const (
+	defaultTCPConnectTimeout = 10 * time.Second
+	defaultTCPReadTimeout    = 5 * time.Second
+	// maxExpectBuffer bounds how much unmatched data is kept while waiting for an expect pattern
+	maxExpectBuffer = 64 << 10
 )
 
 type TCPDriver struct {
-	endpoint string
+	endpoint       string
+	steps          []tcpStep
+	connectTimeout time.Duration
+	readTimeout    time.Duration
+	tlsConfig      *tls.Config
+}
+
+type tcpStep struct {
+	send   []byte
+	expect *regexp.Regexp
 }
 
-func NewTCPDriver(endpoint string) (Driver, error) {
+func NewTCPDriver(endpoint string, check *v1.TcpCheck) (Driver, error) {
 	if endpoint == "" {
 		return nil, fmt.Errorf("endpoint cannot be empty")
 	}
 
-	return &TCPDriver{endpoint: endpoint}, nil
+	t := &TCPDriver{
+		endpoint:       endpoint,
+		connectTimeout: defaultTCPConnectTimeout,
+		readTimeout:    defaultTCPReadTimeout,
+	}
+	if check == nil {
+		return t, nil
+	}
+
+	if check.ConnectTimeout != nil && check.ConnectTimeout.Duration > 0 {
+		t.connectTimeout = check.ConnectTimeout.Duration
+	}
+	if check.ReadTimeout != nil && check.ReadTimeout.Duration > 0 {
+		t.readTimeout = check.ReadTimeout.Duration
+	}
+
+	for i, step := range check.Steps {
+		payload, err := decodePayload(step.Send, step.SendHex)
+		if err != nil {
+			return nil, fmt.Errorf("step %d: %w", i+1, err)
+		}
+		s := tcpStep{send: payload}
+		if step.Expect != "" {
+			s.expect, err = regexp.Compile(step.Expect)
+			if err != nil {
+				return nil, fmt.Errorf("step %d: invalid expect pattern: %w", i+1, err)
+			}
+		}
+		t.steps = append(t.steps, s)
+	}
+
+	t.tlsConfig = buildTLSConfig(check.TLS, endpoint)
+
+	return t, nil
 }
 
 func (t *TCPDriver) Check() (*CheckResult, error) {
 	start := time.Now()
 
-	conn, err := net.DialTimeout("tcp", t.endpoint, 10*time.Second)
-	duration := time.Since(start)
+	conn, err := net.DialTimeout("tcp", t.endpoint, t.connectTimeout)
 
-	result := &CheckResult{
-		ResponseTime: duration,
-	}
+	result := &CheckResult{}
 
 	if err != nil {
+		result.ResponseTime = time.Since(start)
 		result.Success = false
 		result.Error = err
 		result.Message = fmt.Sprintf("TCP check failed: %v", err)
@@ -37,12 +92,114 @@ func (t *TCPDriver) Check() (*CheckResult, error) {
 
 	defer conn.Close()
 
+	if t.tlsConfig != nil {
+		tlsConn := tls.Client(conn, t.tlsConfig)
+		if err := tlsConn.SetDeadline(time.Now().Add(t.connectTimeout)); err == nil {
+			err = tlsConn.Handshake()
+		}
+		if err != nil {
+			result.ResponseTime = time.Since(start)
+			result.Success = false
+			result.Error = err
+			result.Message = fmt.Sprintf("TCP check failed during TLS handshake: %v", err)
+			return result, nil
+		}
+		conn = tlsConn
+	}
+
+	var received []byte
+	for i, step := range t.steps {
+		received, err = t.runStep(conn, step, received)
+		if err != nil {
+			result.ResponseTime = time.Since(start)
+			result.Success = false
+			result.Error = err
+			result.Message = fmt.Sprintf("TCP check failed at step %d: %v", i+1, err)
+			return result, nil
+		}
+	}
+
+	duration := time.Since(start)
+	result.ResponseTime = duration
 	result.Success = true
-	result.Message = fmt.Sprintf("TCP check successful (response time: %v)", duration)
+	if len(t.steps) > 0 {
+		result.Message = fmt.Sprintf("TCP check successful (%d step(s) completed, response time: %v)", len(t.steps), duration)
+	} else {
+		result.Message = fmt.Sprintf("TCP check successful (response time: %v)", duration)
+	}
 
 	return result, nil
 }
 
+// runStep writes the step payload and waits for its expect pattern. Data received
+// after the match is returned so the next step can match against it.
+func (t *TCPDriver) runStep(conn net.Conn, step tcpStep, pending []byte) ([]byte, error) {
+	if len(step.send) > 0 {
+		if err := conn.SetWriteDeadline(time.Now().Add(t.readTimeout)); err != nil {
+			return nil, err
+		}
+		if _, err := conn.Write(step.send); err != nil {
+			return nil, fmt.Errorf("failed to send payload: %w", err)
+		}
+	}
+	if step.expect == nil {
+		return pending, nil
+	}
+
+	if err := conn.SetReadDeadline(time.Now().Add(t.readTimeout)); err != nil {
+		return nil, err
+	}
+
+	buf := bytes.NewBuffer(pending)
+	chunk := make([]byte, 4096)
+	for {
+		if loc := step.expect.FindIndex(buf.Bytes()); loc != nil {
+			return buf.Bytes()[loc[1]:], nil
+		}
+		if buf.Len() > maxExpectBuffer {
+			return nil, fmt.Errorf("no match for /%s/ in the first %d bytes received", step.expect, maxExpectBuffer)
+		}
+
+		n, err := conn.Read(chunk)
+		buf.Write(chunk[:n])
+		if err != nil {
+			if loc := step.expect.FindIndex(buf.Bytes()); loc != nil {
+				return buf.Bytes()[loc[1]:], nil
+			}
+			var netErr net.Error
+			switch {
+			case errors.As(err, &netErr) && netErr.Timeout():
+				return nil, fmt.Errorf("timed out after %v waiting for /%s/, received %q", t.readTimeout, step.expect, preview(buf.Bytes()))
+			case errors.Is(err, io.EOF):
+				return nil, fmt.Errorf("connection closed before /%s/ matched, received %q", step.expect, preview(buf.Bytes()))
+			default:
+				return nil, fmt.Errorf("failed to read response: %w", err)
+			}
+		}
+	}
+}
+
+// decodePayload returns the bytes of a text or hex encoded payload
+func decodePayload(text, hexPayload string) ([]byte, error) {
+	if hexPayload != "" {
+		payload, err := hex.DecodeString(hexPayload)
+		if err != nil {
+			return nil, fmt.Errorf("invalid hex payload: %w", err)
+		}
+		return payload, nil
+	}
+	return []byte(text), nil
+}
+
+// preview shortens received data for error messages
+func preview(data []byte) string {
+	const limit = 128
+	if len(data) > limit {
+		return string(data[:limit]) + "..."
+	}
+	return string(data)
+}
+
 func (t *TCPDriver) GetEndpoint() string {
 	return t.endpoint
 }
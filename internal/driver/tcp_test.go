@@ -0,0 +1,121 @@
+package driver
+
+import (
+	"bufio"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/LiciousTech/endpoint-monitoring-operator/api/v1alpha1"
+)
+
+// startLineServer greets each client and answers PING with +PONG; when wedged it
+// accepts connections but never writes anything.
+func startLineServer(t *testing.T, wedged bool) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func(conn net.Conn) {
+				defer conn.Close()
+				if wedged {
+					time.Sleep(time.Second)
+					return
+				}
+				_, _ = conn.Write([]byte("220 ready\r\n"))
+				scanner := bufio.NewScanner(conn)
+				for scanner.Scan() {
+					if strings.TrimSpace(scanner.Text()) == "PING" {
+						_, _ = conn.Write([]byte("+PONG\r\n"))
+					}
+				}
+			}(conn)
+		}
+	}()
+
+	return ln.Addr().String()
+}
+
+func TestTCPDriverSteps(t *testing.T) {
+	healthy := startLineServer(t, false)
+	wedged := startLineServer(t, true)
+	readTimeout := &metav1.Duration{Duration: 200 * time.Millisecond}
+
+	tests := []struct {
+		name      string
+		endpoint  string
+		check     *v1.TcpCheck
+		success   bool
+		contained string
+	}{
+		{
+			name:     "connect only",
+			endpoint: wedged,
+			success:  true,
+		},
+		{
+			name:     "banner and ping",
+			endpoint: healthy,
+			check: &v1.TcpCheck{Steps: []v1.TcpStep{
+				{Expect: `^220 `},
+				{Send: "PING\r\n", Expect: `\+PONG`},
+			}, ReadTimeout: readTimeout},
+			success: true,
+		},
+		{
+			name:     "hex payload",
+			endpoint: healthy,
+			check: &v1.TcpCheck{Steps: []v1.TcpStep{
+				{SendHex: "50494e470d0a", Expect: `\+PONG`},
+			}, ReadTimeout: readTimeout},
+			success: true,
+		},
+		{
+			name:     "unexpected response",
+			endpoint: healthy,
+			check: &v1.TcpCheck{Steps: []v1.TcpStep{
+				{Send: "PING\r\n", Expect: `^-ERR`},
+			}, ReadTimeout: readTimeout},
+			contained: "received \"220 ready\\r\\n+PONG\\r\\n\"",
+		},
+		{
+			name:     "wedged process",
+			endpoint: wedged,
+			check: &v1.TcpCheck{Steps: []v1.TcpStep{
+				{Send: "PING\r\n", Expect: `\+PONG`},
+			}, ReadTimeout: readTimeout},
+			contained: "timed out",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := NewTCPDriver(tt.endpoint, tt.check)
+			if err != nil {
+				t.Fatalf("unexpected error creating driver: %v", err)
+			}
+			result, err := d.Check()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Success != tt.success {
+				t.Fatalf("expected success=%t, got %t: %s", tt.success, result.Success, result.Message)
+			}
+			if tt.contained != "" && !strings.Contains(result.Message, tt.contained) {
+				t.Errorf("expected message to contain %q, got: %s", tt.contained, result.Message)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package driver
+
+import (
+	"crypto/tls"
+	"net"
+
+	v1 "github.com/LiciousTech/endpoint-monitoring-operator/api/v1alpha1"
+)
+
+// buildTLSConfig returns the client TLS configuration for address, or nil when TLS is disabled
+func buildTLSConfig(cfg *v1.TLSConfig, address string) *tls.Config {
+	if cfg == nil || !cfg.Enabled {
+		return nil
+	}
+
+	serverName := cfg.ServerName
+	if serverName == "" {
+		serverName = address
+		if host, _, err := net.SplitHostPort(address); err == nil {
+			serverName = host
+		}
+	}
+
+	return &tls.Config{
+		ServerName:         serverName,
+		InsecureSkipVerify: cfg.InsecureSkipVerify, //nolint:gosec // explicitly requested per monitor
+		MinVersion:         tls.VersionTLS12,
+	}
+}
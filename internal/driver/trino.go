@@ -1,16 +1,36 @@
 package driver
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
+
+	v1 "github.com/LiciousTech/endpoint-monitoring-operator/api/v1alpha1"
+)
+
+const (
+	defaultTrinoTimeout = 30 * time.Second
+	defaultTrinoUser    = "endpoint-monitor"
+	trinoSource         = "endpoint-monitoring-operator"
+	// trinoRetryDelay is the pause before retrying a statement request the coordinator asked us to repeat
+	trinoRetryDelay = 100 * time.Millisecond
 )
 
 type TrinoDriver struct {
-	endpoint string
-	client   *http.Client
+	endpoint          string
+	client            *http.Client
+	query             string
+	user              string
+	catalog           string
+	schema            string
+	minActiveWorkers  int
+	warningQueryTime  time.Duration
+	criticalQueryTime time.Duration
+	timeout           time.Duration
 }
 
 type NodeVersion struct {
@@ -25,27 +45,76 @@ type TrinoInfoResponse struct {
 	NodeVersion NodeVersion `json:"nodeVersion"`
 }
 
-func NewTrinoDriver(endpoint string) (Driver, error) {
+// trinoQueryResults is a page of the client protocol response from /v1/statement
+type trinoQueryResults struct {
+	ID      string            `json:"id"`
+	NextURI string            `json:"nextUri"`
+	Data    []json.RawMessage `json:"data"`
+	Stats   struct {
+		State string `json:"state"`
+	} `json:"stats"`
+	Error *struct {
+		Message   string `json:"message"`
+		ErrorName string `json:"errorName"`
+	} `json:"error"`
+}
+
+// trinoQuery summarises an executed statement
+type trinoQuery struct {
+	ID       string
+	Rows     int
+	Duration time.Duration
+}
+
+func NewTrinoDriver(endpoint string, check *v1.TrinoCheck) (Driver, error) {
 	if endpoint == "" {
 		return nil, fmt.Errorf("endpoint cannot be empty")
 	}
 
 	endpoint = strings.TrimSuffix(endpoint, "/")
 
-	return &TrinoDriver{
+	t := &TrinoDriver{
 		endpoint: endpoint,
-		client: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-	}, nil
+		user:     defaultTrinoUser,
+		timeout:  defaultTrinoTimeout,
+	}
+	if check != nil {
+		t.query = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(check.Query), ";"))
+		t.catalog = check.Catalog
+		t.schema = check.Schema
+		if check.User != "" {
+			t.user = check.User
+		}
+		if check.MinActiveWorkers != nil {
+			t.minActiveWorkers = int(*check.MinActiveWorkers)
+		}
+		if check.WarningQueryTime != nil {
+			t.warningQueryTime = check.WarningQueryTime.Duration
+		}
+		if check.CriticalQueryTime != nil {
+			t.criticalQueryTime = check.CriticalQueryTime.Duration
+		}
+		if check.Timeout != nil && check.Timeout.Duration > 0 {
+			t.timeout = check.Timeout.Duration
+		}
+		if t.query == "" && (t.warningQueryTime > 0 || t.criticalQueryTime > 0) {
+			return nil, fmt.Errorf("query time thresholds require a query")
+		}
+	}
+	t.client = &http.Client{Timeout: t.timeout}
+
+	return t, nil
 }
 
 func (t *TrinoDriver) Check() (*CheckResult, error) {
 	start := time.Now()
 
+	ctx, cancel := context.WithTimeout(context.Background(), t.timeout)
+	defer cancel()
+
 	infoURL := t.endpoint + "/v1/info"
 
-	resp, err := t.client.Get(infoURL)
+	resp, err := t.get(ctx, infoURL)
 	duration := time.Since(start)
 
 	result := &CheckResult{
@@ -61,6 +130,8 @@ func (t *TrinoDriver) Check() (*CheckResult, error) {
 
 	defer resp.Body.Close()
 
+	result.StatusCode = resp.StatusCode
+
 	if resp.StatusCode != 200 {
 		result.Success = false
 		result.Message = fmt.Sprintf("Trino check failed (status: %d, response time: %v)", resp.StatusCode, duration)
@@ -74,24 +145,222 @@ func (t *TrinoDriver) Check() (*CheckResult, error) {
 		result.Message = fmt.Sprintf("Trino check failed to parse response: %v", err)
 		return result, nil
 	}
+	result.Details = toDetails(trinoInfo)
+
+	summary := fmt.Sprintf("coordinator: %t, starting: %t, uptime: %s, version: %s, env: %s",
+		trinoInfo.Coordinator, trinoInfo.Starting, trinoInfo.Uptime, trinoInfo.NodeVersion.Version, trinoInfo.Environment)
 
 	// Trino is healthy if:
 	// 1. It's a coordinator node (coordinator: true)
 	// 2. It has finished starting (starting: false)
 	// 3. API is responding (we already checked HTTP 200)
-	if trinoInfo.Coordinator && !trinoInfo.Starting {
-		result.Success = true
-		result.Message = fmt.Sprintf("Trino check successful (coordinator: %t, starting: %t, uptime: %s, version: %s, env: %s, response time: %v)",
-			trinoInfo.Coordinator, trinoInfo.Starting, trinoInfo.Uptime, trinoInfo.NodeVersion.Version, trinoInfo.Environment, duration)
-	} else {
+	if !trinoInfo.Coordinator || trinoInfo.Starting {
 		result.Success = false
-		result.Message = fmt.Sprintf("Trino check failed (coordinator: %t, starting: %t, uptime: %s, version: %s, env: %s, response time: %v)",
-			trinoInfo.Coordinator, trinoInfo.Starting, trinoInfo.Uptime, trinoInfo.NodeVersion.Version, trinoInfo.Environment, duration)
+		result.Message = fmt.Sprintf("Trino check failed (%s, response time: %v)", summary, duration)
+		return result, nil
+	}
+
+	if t.minActiveWorkers > 0 {
+		workers, err := t.activeWorkers(ctx)
+		if err != nil {
+			result.ResponseTime = time.Since(start)
+			result.Success = false
+			result.Error = err
+			result.Message = fmt.Sprintf("Trino check failed to list active nodes: %v", err)
+			return result, nil
+		}
+		result.Details["activeWorkers"] = workers
+		summary += fmt.Sprintf(", active workers: %d", workers)
+		if workers < t.minActiveWorkers {
+			result.ResponseTime = time.Since(start)
+			result.Success = false
+			result.Message = fmt.Sprintf("Trino check failed, %d active worker(s), expected at least %d (%s, response time: %v)",
+				workers, t.minActiveWorkers, summary, result.ResponseTime)
+			return result, nil
+		}
 	}
 
+	if t.query != "" {
+		query, err := t.runQuery(ctx)
+		result.ResponseTime = time.Since(start)
+		if err != nil {
+			result.Success = false
+			result.Error = err
+			result.Message = fmt.Sprintf("Trino check failed, query %q: %v (%s)", t.query, err, summary)
+			return result, nil
+		}
+		result.Details["queryId"] = query.ID
+		result.Details["queryRows"] = query.Rows
+		result.Details["queryTime"] = query.Duration.Seconds()
+		summary += fmt.Sprintf(", query %s returned %d row(s) in %v", query.ID, query.Rows, query.Duration)
+
+		switch {
+		case t.criticalQueryTime > 0 && query.Duration > t.criticalQueryTime:
+			result.Success = false
+			result.Message = fmt.Sprintf("Trino check failed, query time %v exceeds critical query time %v (%s, response time: %v)",
+				query.Duration, t.criticalQueryTime, summary, result.ResponseTime)
+			return result, nil
+		case t.warningQueryTime > 0 && query.Duration > t.warningQueryTime:
+			result.Success = true
+			result.Degraded = true
+			result.Message = fmt.Sprintf("Trino query slow, query time %v exceeds warning query time %v (%s, response time: %v)",
+				query.Duration, t.warningQueryTime, summary, result.ResponseTime)
+			return result, nil
+		}
+	}
+
+	result.ResponseTime = time.Since(start)
+	result.Success = true
+	result.Message = fmt.Sprintf("Trino check successful (%s, response time: %v)", summary, result.ResponseTime)
+
 	return result, nil
 }
 
+// activeWorkers returns the number of nodes the coordinator currently considers active.
+// /v1/node also lists nodes the failure detector has marked failed until their
+// announcements expire, so those listed at /v1/node/failed are not counted.
+func (t *TrinoDriver) activeWorkers(ctx context.Context) (int, error) {
+	nodes, err := t.nodeURIs(ctx, "/v1/node")
+	if err != nil {
+		return 0, err
+	}
+	failed, err := t.nodeURIs(ctx, "/v1/node/failed")
+	if err != nil {
+		return 0, err
+	}
+
+	active := 0
+	for _, uri := range nodes {
+		if !slices.Contains(failed, uri) {
+			active++
+		}
+	}
+	return active, nil
+}
+
+// nodeURIs lists the URIs of the nodes returned by a node endpoint
+func (t *TrinoDriver) nodeURIs(ctx context.Context, path string) ([]string, error) {
+	resp, err := t.get(ctx, t.endpoint+path)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: unexpected status %d", path, resp.StatusCode)
+	}
+	var nodes []struct {
+		URI string `json:"uri"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&nodes); err != nil {
+		return nil, fmt.Errorf("%s: failed to parse response: %w", path, err)
+	}
+	uris := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		uris = append(uris, node.URI)
+	}
+	return uris, nil
+}
+
+// runQuery submits the configured statement and follows nextUri until the query finishes
+func (t *TrinoDriver) runQuery(ctx context.Context) (*trinoQuery, error) {
+	start := time.Now()
+	query := &trinoQuery{}
+
+	page, err := t.statementRequest(ctx, http.MethodPost, t.endpoint+"/v1/statement", t.query)
+	for err == nil {
+		query.ID = page.ID
+		query.Rows += len(page.Data)
+		if page.Error != nil {
+			return nil, fmt.Errorf("%s: %s", page.Error.ErrorName, page.Error.Message)
+		}
+		if page.NextURI == "" {
+			break
+		}
+		next := page.NextURI
+		if page, err = t.statementRequest(ctx, http.MethodGet, next, ""); err != nil && ctx.Err() != nil {
+			t.cancelQuery(next)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	query.Duration = time.Since(start)
+	return query, nil
+}
+
+// statementRequest performs one step of the client protocol, retrying while the coordinator is busy
+func (t *TrinoDriver) statementRequest(ctx context.Context, method, url, body string) (*trinoQueryResults, error) {
+	for {
+		req, err := http.NewRequestWithContext(ctx, method, url, strings.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		t.setHeaders(req)
+
+		resp, err := t.client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var page trinoQueryResults
+			err := json.NewDecoder(resp.Body).Decode(&page)
+			resp.Body.Close()
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse response: %w", err)
+			}
+			return &page, nil
+		case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			resp.Body.Close()
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(trinoRetryDelay):
+			}
+		default:
+			resp.Body.Close()
+			return nil, fmt.Errorf("unexpected status %d from %s", resp.StatusCode, url)
+		}
+	}
+}
+
+// cancelQuery asks the coordinator to abandon a query we stopped following
+func (t *TrinoDriver) cancelQuery(nextURI string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, nextURI, nil)
+	if err != nil {
+		return
+	}
+	t.setHeaders(req)
+	if resp, err := t.client.Do(req); err == nil {
+		resp.Body.Close()
+	}
+}
+
+func (t *TrinoDriver) get(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	t.setHeaders(req)
+	return t.client.Do(req)
+}
+
+func (t *TrinoDriver) setHeaders(req *http.Request) {
+	req.Header.Set("X-Trino-User", t.user)
+	req.Header.Set("X-Trino-Source", trinoSource)
+	if t.catalog != "" {
+		req.Header.Set("X-Trino-Catalog", t.catalog)
+	}
+	if t.schema != "" {
+		req.Header.Set("X-Trino-Schema", t.schema)
+	}
+}
+
 func (t *TrinoDriver) GetEndpoint() string {
 	return t.endpoint
 }
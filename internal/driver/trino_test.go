@@ -0,0 +1,161 @@
+package driver
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/LiciousTech/endpoint-monitoring-operator/api/v1alpha1"
+)
+
+// startTrinoServer fakes a coordinator with the given number of active nodes. Queries
+// take two pages and the first poll is answered with 503 to exercise retries;
+// "SELECT * FROM broken" fails and "SELECT slow" sleeps before finishing.
+func startTrinoServer(t *testing.T, nodes, failed int) (*httptest.Server, *atomic.Value) {
+	t.Helper()
+	var lastHeaders atomic.Value
+	var busy atomic.Bool
+	var server *httptest.Server
+
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastHeaders.Store(r.Header.Clone())
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.URL.Path == "/v1/info":
+			fmt.Fprint(w, `{"coordinator":true,"starting":false,"uptime":"1.00d","environment":"test","nodeVersion":{"version":"446"}}`)
+		case r.URL.Path == "/v1/node":
+			list := make([]map[string]string, nodes)
+			for i := range list {
+				list[i] = map[string]string{"uri": fmt.Sprintf("http://worker-%d:8080", i)}
+			}
+			_ = json.NewEncoder(w).Encode(list)
+		case r.URL.Path == "/v1/node/failed":
+			list := make([]map[string]string, failed)
+			for i := range list {
+				list[i] = map[string]string{"uri": fmt.Sprintf("http://worker-%d:8080", nodes-1-i)}
+			}
+			_ = json.NewEncoder(w).Encode(list)
+		case r.URL.Path == "/v1/statement" && r.Method == http.MethodPost:
+			body, _ := io.ReadAll(r.Body)
+			switch string(body) {
+			case "SELECT * FROM broken":
+				fmt.Fprint(w, `{"id":"q2","stats":{"state":"FAILED"},"error":{"message":"line 1:15: Table 'hive.default.broken' does not exist","errorName":"TABLE_NOT_FOUND"}}`)
+			case "SELECT slow":
+				time.Sleep(150 * time.Millisecond)
+				fmt.Fprintf(w, `{"id":"q3","nextUri":"%s/v1/statement/executing/q3/1","stats":{"state":"QUEUED"}}`, server.URL)
+			default:
+				busy.Store(true)
+				fmt.Fprintf(w, `{"id":"q1","nextUri":"%s/v1/statement/executing/q1/1","stats":{"state":"QUEUED"}}`, server.URL)
+			}
+		case strings.HasPrefix(r.URL.Path, "/v1/statement/executing/"):
+			if busy.CompareAndSwap(true, false) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			id := strings.Split(r.URL.Path, "/")[4]
+			fmt.Fprintf(w, `{"id":"%s","columns":[{"name":"_col0","type":"integer"}],"data":[[1]],"stats":{"state":"FINISHED"}}`, id)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, &lastHeaders
+}
+
+func TestTrinoDriver(t *testing.T) {
+	server, lastHeaders := startTrinoServer(t, 4, 2)
+	minWorkers, availableWorkers := int32(3), int32(2)
+	warning := &metav1.Duration{Duration: 50 * time.Millisecond}
+	critical := &metav1.Duration{Duration: 100 * time.Millisecond}
+
+	tests := []struct {
+		name      string
+		check     *v1.TrinoCheck
+		success   bool
+		degraded  bool
+		contained string
+	}{
+		{
+			name:      "coordinator info only",
+			success:   true,
+			contained: "coordinator: true, starting: false",
+		},
+		{
+			name:      "too few workers",
+			check:     &v1.TrinoCheck{MinActiveWorkers: &minWorkers},
+			contained: "2 active worker(s), expected at least 3",
+		},
+		{
+			name:      "failed workers are not counted",
+			check:     &v1.TrinoCheck{MinActiveWorkers: &availableWorkers},
+			success:   true,
+			contained: "active workers: 2",
+		},
+		{
+			name:      "paged query",
+			check:     &v1.TrinoCheck{Query: "SELECT 1;", Catalog: "hive", Schema: "sales", User: "probe"},
+			success:   true,
+			contained: "query q1 returned 1 row(s)",
+		},
+		{
+			name:      "failing query",
+			check:     &v1.TrinoCheck{Query: "SELECT * FROM broken"},
+			contained: "TABLE_NOT_FOUND: line 1:15: Table 'hive.default.broken' does not exist",
+		},
+		{
+			name:      "slow query degrades",
+			check:     &v1.TrinoCheck{Query: "SELECT slow", WarningQueryTime: warning},
+			success:   true,
+			degraded:  true,
+			contained: "exceeds warning query time 50ms",
+		},
+		{
+			name:      "very slow query fails",
+			check:     &v1.TrinoCheck{Query: "SELECT slow", WarningQueryTime: warning, CriticalQueryTime: critical},
+			contained: "exceeds critical query time 100ms",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := NewTrinoDriver(server.URL, tt.check)
+			if err != nil {
+				t.Fatalf("unexpected error creating driver: %v", err)
+			}
+			result, err := d.Check()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Success != tt.success || result.Degraded != tt.degraded {
+				t.Fatalf("expected success=%t degraded=%t, got %t/%t: %s",
+					tt.success, tt.degraded, result.Success, result.Degraded, result.Message)
+			}
+			if tt.contained != "" && !strings.Contains(result.Message, tt.contained) {
+				t.Errorf("expected message to contain %q, got: %s", tt.contained, result.Message)
+			}
+		})
+	}
+
+	headers := lastHeaders.Load().(http.Header)
+	if headers.Get("X-Trino-User") != defaultTrinoUser {
+		t.Errorf("expected default user header, got %q", headers.Get("X-Trino-User"))
+	}
+
+	d, _ := NewTrinoDriver(server.URL, &v1.TrinoCheck{Query: "SELECT 1", Catalog: "hive", Schema: "sales", User: "probe"})
+	if _, err := d.Check(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	headers = lastHeaders.Load().(http.Header)
+	if headers.Get("X-Trino-User") != "probe" || headers.Get("X-Trino-Catalog") != "hive" || headers.Get("X-Trino-Schema") != "sales" {
+		t.Errorf("expected session headers to be sent, got %v", headers)
+	}
+}
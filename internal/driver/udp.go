@@ -0,0 +1,167 @@
+package driver
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"syscall"
+	"time"
+
+	v1 "github.com/LiciousTech/endpoint-monitoring-operator/api/v1alpha1"
+)
+
+const (
+	defaultUDPTimeout = 2 * time.Second
+	defaultUDPRetries = 2
+)
+
+type UDPDriver struct {
+	endpoint string
+	payload  []byte
+	expect   *regexp.Regexp
+	timeout  time.Duration
+	retries  int
+}
+
+func NewUDPDriver(endpoint string, check *v1.UdpCheck) (Driver, error) {
+	if endpoint == "" {
+		return nil, fmt.Errorf("endpoint cannot be empty")
+	}
+
+	u := &UDPDriver{
+		endpoint: endpoint,
+		timeout:  defaultUDPTimeout,
+		retries:  defaultUDPRetries,
+	}
+	if check == nil {
+		return u, nil
+	}
+
+	payload, err := decodePayload(check.Send, check.SendHex)
+	if err != nil {
+		return nil, err
+	}
+	u.payload = payload
+
+	if check.Expect != "" {
+		u.expect, err = regexp.Compile(check.Expect)
+		if err != nil {
+			return nil, fmt.Errorf("invalid expect pattern: %w", err)
+		}
+	}
+	if check.Timeout != nil && check.Timeout.Duration > 0 {
+		u.timeout = check.Timeout.Duration
+	}
+	if check.Retries != nil {
+		if *check.Retries < 0 {
+			return nil, fmt.Errorf("retries cannot be negative")
+		}
+		u.retries = int(*check.Retries)
+	}
+
+	return u, nil
+}
+
+func (u *UDPDriver) Check() (*CheckResult, error) {
+	start := time.Now()
+	result := &CheckResult{}
+
+	// A connected socket reports ICMP port-unreachable as ECONNREFUSED on read
+	conn, err := net.DialTimeout("udp", u.endpoint, u.timeout)
+	if err != nil {
+		result.ResponseTime = time.Since(start)
+		result.Success = false
+		result.Error = err
+		result.Message = fmt.Sprintf("UDP check failed: %v", err)
+		return result, nil
+	}
+	defer conn.Close()
+
+	buf := make([]byte, 65535)
+	var lastResponse []byte
+	attempts := u.retries + 1
+
+	for attempt := 1; attempt <= attempts; attempt++ {
+		sentAt := time.Now()
+		if _, err := conn.Write(u.payload); err != nil {
+			if errors.Is(err, syscall.ECONNREFUSED) {
+				return u.refused(result, start), nil
+			}
+			result.ResponseTime = time.Since(start)
+			result.Success = false
+			result.Error = err
+			result.Message = fmt.Sprintf("UDP check failed to send payload: %v", err)
+			return result, nil
+		}
+
+		if err := conn.SetReadDeadline(time.Now().Add(u.timeout)); err != nil {
+			result.ResponseTime = time.Since(start)
+			result.Success = false
+			result.Error = err
+			result.Message = fmt.Sprintf("UDP check failed to set read deadline: %v", err)
+			return result, nil
+		}
+
+		for {
+			n, err := conn.Read(buf)
+			if err != nil {
+				if errors.Is(err, syscall.ECONNREFUSED) {
+					return u.refused(result, start), nil
+				}
+				var netErr net.Error
+				if errors.As(err, &netErr) && netErr.Timeout() {
+					break
+				}
+				result.ResponseTime = time.Since(start)
+				result.Success = false
+				result.Error = err
+				result.Message = fmt.Sprintf("UDP check failed to read response: %v", err)
+				return result, nil
+			}
+
+			lastResponse = append(lastResponse[:0], buf[:n]...)
+			if u.expect == nil || u.expect.Match(lastResponse) {
+				result.ResponseTime = time.Since(sentAt)
+				result.Success = true
+				result.Message = fmt.Sprintf("UDP check successful (response received on attempt %d/%d, %d bytes, response time: %v)",
+					attempt, attempts, n, result.ResponseTime)
+				return result, nil
+			}
+			// Keep reading until the deadline; a stale reply to an earlier attempt may arrive first
+		}
+	}
+
+	result.ResponseTime = time.Since(start)
+	switch {
+	case u.expect == nil:
+		// Fire-and-forget services (syslog, StatsD) never answer; silence without port-unreachable is healthy
+		result.Success = true
+		result.Message = fmt.Sprintf("UDP check successful (no response and no ICMP port unreachable after %d attempt(s), response time: %v)",
+			attempts, result.ResponseTime)
+	case lastResponse != nil:
+		result.Success = false
+		result.Message = fmt.Sprintf("UDP check failed, response did not match /%s/ after %d attempt(s), last response %q",
+			u.expect, attempts, preview(lastResponse))
+	default:
+		result.Success = false
+		result.Message = fmt.Sprintf("UDP check failed, no response after %d attempt(s) of %v", attempts, u.timeout)
+	}
+	return result, nil
+}
+
+func (u *UDPDriver) refused(result *CheckResult, start time.Time) *CheckResult {
+	result.ResponseTime = time.Since(start)
+	result.Success = false
+	result.Error = syscall.ECONNREFUSED
+	result.Message = fmt.Sprintf("UDP check failed, ICMP port unreachable from %s (response time: %v)", u.endpoint, result.ResponseTime)
+	return result
+}
+
+func (u *UDPDriver) GetEndpoint() string {
+	return u.endpoint
+}
+
+func (u *UDPDriver) GetType() string {
+	return "udp"
+}
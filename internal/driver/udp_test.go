@@ -0,0 +1,109 @@
+package driver
+
+import (
+	"bytes"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/LiciousTech/endpoint-monitoring-operator/api/v1alpha1"
+)
+
+// startUDPServer answers "status" with "ok" and ignores everything else
+func startUDPServer(t *testing.T) string {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	go func() {
+		buf := make([]byte, 1024)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			if bytes.Equal(buf[:n], []byte("status")) {
+				_, _ = conn.WriteTo([]byte("ok"), addr)
+			}
+		}
+	}()
+
+	return conn.LocalAddr().String()
+}
+
+// closedUDPPort returns an address where nothing listens
+func closedUDPPort(t *testing.T) string {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	addr := conn.LocalAddr().String()
+	_ = conn.Close()
+	return addr
+}
+
+func TestUDPDriver(t *testing.T) {
+	server := startUDPServer(t)
+	timeout := &metav1.Duration{Duration: 100 * time.Millisecond}
+	noRetries := int32(0)
+
+	tests := []struct {
+		name      string
+		endpoint  string
+		check     *v1.UdpCheck
+		success   bool
+		contained string
+	}{
+		{
+			name:     "matching response",
+			endpoint: server,
+			check:    &v1.UdpCheck{Send: "status", Expect: "^ok$", Timeout: timeout},
+			success:  true,
+		},
+		{
+			name:      "silent service with fire-and-forget payload",
+			endpoint:  server,
+			check:     &v1.UdpCheck{SendHex: "6d65747269633a317c63", Timeout: timeout, Retries: &noRetries},
+			success:   true,
+			contained: "no response and no ICMP port unreachable",
+		},
+		{
+			name:      "no response when one is expected",
+			endpoint:  server,
+			check:     &v1.UdpCheck{Send: "metric:1|c", Expect: ".", Timeout: timeout},
+			contained: "no response after 3 attempt(s)",
+		},
+		{
+			name:      "port unreachable",
+			endpoint:  closedUDPPort(t),
+			check:     &v1.UdpCheck{Send: "status", Timeout: timeout},
+			contained: "ICMP port unreachable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := NewUDPDriver(tt.endpoint, tt.check)
+			if err != nil {
+				t.Fatalf("unexpected error creating driver: %v", err)
+			}
+			result, err := d.Check()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Success != tt.success {
+				t.Fatalf("expected success=%t, got %t: %s", tt.success, result.Success, result.Message)
+			}
+			if tt.contained != "" && !strings.Contains(result.Message, tt.contained) {
+				t.Errorf("expected message to contain %q, got: %s", tt.contained, result.Message)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	// CheckStatus is 1 for the status reported by the latest check of a monitor and 0 for the others
+	CheckStatus = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "endpointmonitor_check_status",
+			Help: "Status of the latest check (1 for the current status, 0 otherwise)",
+		},
+		[]string{"namespace", "name", "driver", "status"},
+	)
+
+	// ResponseTime is the response time of the latest check in seconds
+	ResponseTime = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "endpointmonitor_response_time_seconds",
+			Help: "Response time of the latest check in seconds",
+		},
+		[]string{"namespace", "name", "driver"},
+	)
+
+	// ChecksTotal counts completed checks by resulting status
+	ChecksTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "endpointmonitor_checks_total",
+			Help: "Total number of checks performed, by resulting status",
+		},
+		[]string{"namespace", "name", "driver", "status"},
+	)
+
+	// SLOAvailability is the availability of an EndpointSLO over its window
+	SLOAvailability = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "endpointslo_availability_ratio",
+			Help: "Fraction of successful checks over the SLO window",
+		},
+		[]string{"namespace", "name"},
+	)
+
+	// SLOErrorBudgetRemaining is the fraction of the error budget left, negative once overspent
+	SLOErrorBudgetRemaining = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "endpointslo_error_budget_remaining_ratio",
+			Help: "Fraction of the error budget left over the SLO window",
+		},
+		[]string{"namespace", "name"},
+	)
+
+	// SLOBurnRate is the burn rate over the long and short window of each alert
+	SLOBurnRate = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "endpointslo_burn_rate",
+			Help: "Error budget burn rate over an alert window (1 spends the budget exactly over the SLO window)",
+		},
+		[]string{"namespace", "name", "alert", "window"},
+	)
+
+	// SLOAlertFiring is 1 while a burn-rate alert fires
+	SLOAlertFiring = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "endpointslo_alert_firing",
+			Help: "Whether a burn-rate alert is firing (1) or not (0)",
+		},
+		[]string{"namespace", "name", "alert"},
+	)
+)
+
+// statuses lists every value CheckStatus is reported for
+var statuses = []string{"success", "degraded", "failure"}
+
+func init() {
+	metrics.Registry.MustRegister(CheckStatus, ResponseTime, ChecksTotal,
+		SLOAvailability, SLOErrorBudgetRemaining, SLOBurnRate, SLOAlertFiring)
+}
+
+// RecordCheck updates all check metrics for a monitor
+func RecordCheck(namespace, name, driver, status string, responseTime time.Duration) {
+	for _, s := range statuses {
+		value := 0.0
+		if s == status {
+			value = 1
+		}
+		CheckStatus.WithLabelValues(namespace, name, driver, s).Set(value)
+	}
+	ResponseTime.WithLabelValues(namespace, name, driver).Set(responseTime.Seconds())
+	ChecksTotal.WithLabelValues(namespace, name, driver, status).Inc()
+}
+
+// Forget removes all series of a deleted monitor
+func Forget(namespace, name string) {
+	labels := prometheus.Labels{"namespace": namespace, "name": name}
+	CheckStatus.DeletePartialMatch(labels)
+	ResponseTime.DeletePartialMatch(labels)
+	ChecksTotal.DeletePartialMatch(labels)
+}
+
+// SLOBurn is the evaluation of one burn-rate alert
+type SLOBurn struct {
+	Alert     string
+	LongBurn  float64
+	ShortBurn float64
+	Firing    bool
+}
+
+// RecordSLO updates all metrics of an EndpointSLO
+func RecordSLO(namespace, name string, availability, budgetRemaining float64, burns []SLOBurn) {
+	ForgetSLO(namespace, name)
+	SLOAvailability.WithLabelValues(namespace, name).Set(availability)
+	SLOErrorBudgetRemaining.WithLabelValues(namespace, name).Set(budgetRemaining)
+	for _, burn := range burns {
+		firing := 0.0
+		if burn.Firing {
+			firing = 1
+		}
+		SLOBurnRate.WithLabelValues(namespace, name, burn.Alert, "long").Set(burn.LongBurn)
+		SLOBurnRate.WithLabelValues(namespace, name, burn.Alert, "short").Set(burn.ShortBurn)
+		SLOAlertFiring.WithLabelValues(namespace, name, burn.Alert).Set(firing)
+	}
+}
+
+// ForgetSLO removes all series of an EndpointSLO, including those of removed alerts
+func ForgetSLO(namespace, name string) {
+	labels := prometheus.Labels{"namespace": namespace, "name": name}
+	SLOAvailability.DeletePartialMatch(labels)
+	SLOErrorBudgetRemaining.DeletePartialMatch(labels)
+	SLOBurnRate.DeletePartialMatch(labels)
+	SLOAlertFiring.DeletePartialMatch(labels)
+}
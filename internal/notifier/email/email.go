@@ -38,7 +38,15 @@ func (e *EmailNotifier) SendAlert(status string, msg string) error {
 }
 
 func (e *EmailNotifier) shouldAlert(status string) bool {
-	// Placeholder: extend EmailConfig with AlertOn []string if needed
-	// For now, default to failure only
-	return status == "failure"
+	if len(e.config.AlertOn) == 0 {
+		// Default to alert only on failure
+		return status == "failure"
+	}
+
+	for _, allowed := range e.config.AlertOn {
+		if allowed == status {
+			return true
+		}
+	}
+	return false
 }
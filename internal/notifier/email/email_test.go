@@ -0,0 +1,36 @@
+package email
+
+import (
+	"testing"
+
+	"github.com/LiciousTech/endpoint-monitoring-operator/api/v1alpha1"
+)
+
+func TestEmailNotifierShouldAlert(t *testing.T) {
+	tests := []struct {
+		name     string
+		alertOn  []string
+		status   string
+		expected bool
+	}{
+		{name: "default alerts on failure", status: "failure", expected: true},
+		{name: "default skips degraded", status: "degraded", expected: false},
+		{name: "default skips success", status: "success", expected: false},
+		{name: "explicit degraded", alertOn: []string{"failure", "degraded"}, status: "degraded", expected: true},
+		{name: "explicit failure", alertOn: []string{"failure", "degraded"}, status: "failure", expected: true},
+		{name: "status not listed", alertOn: []string{"failure", "degraded"}, status: "success", expected: false},
+		{name: "unknown status", alertOn: []string{"degraded"}, status: "unknown", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, err := New(&v1alpha1.EmailConfig{Enabled: true, From: "monitor@example.com", To: []string{"oncall@example.com"}, AlertOn: tt.alertOn})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := n.(*EmailNotifier).shouldAlert(tt.status); got != tt.expected {
+				t.Errorf("shouldAlert(%q) = %t, want %t", tt.status, got, tt.expected)
+			}
+		})
+	}
+}
@@ -27,6 +27,9 @@ func (s *SlackNotifier) SendAlert(status string, msg string) error {
 	}
 
 	payload := map[string]string{"text": msg}
+	if s.cfg.Channel != "" {
+		payload["channel"] = s.cfg.Channel
+	}
 	jsonData, err := json.Marshal(payload)
 	if err != nil {
 		return fmt.Errorf("failed to marshal slack payload: %w", err)
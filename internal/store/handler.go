@@ -0,0 +1,91 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// HandlerPath is where the manager serves the query API, next to /metrics
+const HandlerPath = "/history"
+
+// defaultQueryRange is the range queried when from is omitted
+const defaultQueryRange = 24 * time.Hour
+
+// historyResponse is the body returned by the query API
+type historyResponse struct {
+	Monitor      string   `json:"monitor"`
+	From         string   `json:"from"`
+	To           string   `json:"to"`
+	Summary      Summary  `json:"summary"`
+	Availability float64  `json:"availability"`
+	Results      []Result `json:"results,omitempty"`
+	Rollups      []Rollup `json:"rollups,omitempty"`
+}
+
+// Handler serves the history of one monitor as JSON:
+//
+//	GET /history?namespace=<ns>&name=<name>[&from=<RFC 3339>][&to=<RFC 3339>][&resolution=raw|hourly]
+//
+// The range defaults to the last 24 hours and the resolution to raw results.
+func (s *Store) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		query := r.URL.Query()
+		namespace, name := query.Get("namespace"), query.Get("name")
+		if namespace == "" || name == "" {
+			http.Error(w, "namespace and name are required", http.StatusBadRequest)
+			return
+		}
+
+		now := time.Now()
+		to, err := parseQueryTime(query.Get("to"), now)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		from, err := parseQueryTime(query.Get("from"), to.Add(-defaultQueryRange))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		monitor := namespace + "/" + name
+		response := historyResponse{Monitor: monitor, From: from.Format(time.RFC3339), To: to.Format(time.RFC3339)}
+		switch resolution := query.Get("resolution"); resolution {
+		case "", "raw":
+			response.Results, err = s.Results(monitor, from, to)
+		case "hourly":
+			response.Rollups, err = s.Rollups(monitor, from.Truncate(time.Hour), to)
+		default:
+			http.Error(w, fmt.Sprintf("unsupported resolution %q", resolution), http.StatusBadRequest)
+			return
+		}
+		if err == nil {
+			response.Summary, err = s.Summarize(monitor, from, to, now)
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		response.Availability = response.Summary.Availability()
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	})
+}
+
+func parseQueryTime(value string, fallback time.Time) (time.Time, error) {
+	if value == "" {
+		return fallback, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q, expected RFC 3339", value)
+	}
+	return t, nil
+}
@@ -0,0 +1,370 @@
+// Package store persists check results in an embedded bbolt database so that
+// history outlives the few entries kept in EndpointMonitor status.
+//
+// Every monitor has a top-level bucket named "<namespace>/<name>" holding two
+// nested buckets: individual results keyed by their time, and hourly rollups
+// keyed by the start of the hour. Results are downsampled into the rollups as
+// they are recorded, so pruning only has to delete expired keys.
+package store
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var (
+	resultsBucket = []byte("results")
+	hourlyBucket  = []byte("hourly")
+)
+
+// statuses maps the check status to its on-disk code; the index is the code
+var statuses = []string{"success", "degraded", "failure"}
+
+const (
+	// DefaultRetention is how long hourly rollups are kept
+	DefaultRetention = 90 * 24 * time.Hour
+	// DefaultRawRetention is how long individual results are kept
+	DefaultRawRetention = 7 * 24 * time.Hour
+
+	pruneInterval = time.Hour
+	openTimeout   = 10 * time.Second
+)
+
+// Options configures retention of a Store
+type Options struct {
+	Retention    time.Duration // how long hourly rollups are kept, DefaultRetention if zero
+	RawRetention time.Duration // how long individual results are kept, DefaultRawRetention if zero
+}
+
+// Result is one persisted check
+type Result struct {
+	Time         time.Time     `json:"time"`
+	Status       string        `json:"status"`
+	ResponseTime time.Duration `json:"responseTime"`
+	Message      string        `json:"message,omitempty"`
+}
+
+// Rollup aggregates the checks started within one hour
+type Rollup struct {
+	Start      time.Time     `json:"start"`
+	Checks     int64         `json:"checks"`
+	Failures   int64         `json:"failures"`
+	Degraded   int64         `json:"degraded"`
+	LatencySum time.Duration `json:"latencySum"`
+	LatencyMax time.Duration `json:"latencyMax"`
+}
+
+// Summary aggregates the checks of a time range
+type Summary struct {
+	Checks         int64         `json:"checks"`
+	Failures       int64         `json:"failures"`
+	Degraded       int64         `json:"degraded"`
+	AverageLatency time.Duration `json:"averageLatency"`
+	MaxLatency     time.Duration `json:"maxLatency"`
+}
+
+// Availability is the fraction of checks that were not failures, or 1 without checks
+func (s Summary) Availability() float64 {
+	if s.Checks == 0 {
+		return 1
+	}
+	return float64(s.Checks-s.Failures) / float64(s.Checks)
+}
+
+// Store is a bbolt database of check results
+type Store struct {
+	db   *bolt.DB
+	opts Options
+}
+
+// Open opens or creates the database at path. Only one process can hold it,
+// so a second replica fails here instead of corrupting the file.
+func Open(path string, opts Options) (*Store, error) {
+	if opts.Retention <= 0 {
+		opts.Retention = DefaultRetention
+	}
+	if opts.RawRetention <= 0 {
+		opts.RawRetention = DefaultRawRetention
+	}
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: openTimeout})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open check history %s: %w", path, err)
+	}
+	return &Store{db: db, opts: opts}, nil
+}
+
+// Close closes the database
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Record persists a result of monitor ("<namespace>/<name>") and counts it in its hourly rollup
+func (s *Store) Record(monitor string, result Result) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		root, err := tx.CreateBucketIfNotExists([]byte(monitor))
+		if err != nil {
+			return err
+		}
+		results, err := root.CreateBucketIfNotExists(resultsBucket)
+		if err != nil {
+			return err
+		}
+		hourly, err := root.CreateBucketIfNotExists(hourlyBucket)
+		if err != nil {
+			return err
+		}
+
+		if err := results.Put(timeKey(result.Time), encodeResult(result)); err != nil {
+			return err
+		}
+
+		hour := result.Time.Truncate(time.Hour)
+		rollup := Rollup{Start: hour}
+		if value := hourly.Get(timeKey(hour)); value != nil {
+			if err := json.Unmarshal(value, &rollup); err != nil {
+				return fmt.Errorf("corrupt rollup of %s at %s: %w", monitor, hour, err)
+			}
+		}
+		rollup.add(result)
+		value, err := json.Marshal(rollup)
+		if err != nil {
+			return err
+		}
+		return hourly.Put(timeKey(hour), value)
+	})
+}
+
+// Results returns the individual results of monitor in [from, to), oldest first.
+// Results older than the raw retention may already be pruned.
+func (s *Store) Results(monitor string, from, to time.Time) ([]Result, error) {
+	var out []Result
+	err := s.scan(monitor, resultsBucket, from, to, func(key, value []byte) error {
+		result, err := decodeResult(key, value)
+		if err != nil {
+			return fmt.Errorf("corrupt result of %s: %w", monitor, err)
+		}
+		out = append(out, result)
+		return nil
+	})
+	return out, err
+}
+
+// Rollups returns the hourly rollups of monitor starting in [from, to), oldest first
+func (s *Store) Rollups(monitor string, from, to time.Time) ([]Rollup, error) {
+	var out []Rollup
+	err := s.scan(monitor, hourlyBucket, from, to, func(_, value []byte) error {
+		var rollup Rollup
+		if err := json.Unmarshal(value, &rollup); err != nil {
+			return fmt.Errorf("corrupt rollup of %s: %w", monitor, err)
+		}
+		out = append(out, rollup)
+		return nil
+	})
+	return out, err
+}
+
+// Summarize aggregates the checks of monitor in [from, to). Whole hours before the
+// raw retention are taken from the rollups and everything later from the results.
+func (s *Store) Summarize(monitor string, from, to, now time.Time) (Summary, error) {
+	rawFrom := now.Add(-s.opts.RawRetention).Truncate(time.Hour).Add(time.Hour)
+	var summary Summary
+	var latency time.Duration
+
+	if from.Before(rawFrom) {
+		rollups, err := s.Rollups(monitor, from.Truncate(time.Hour), minTime(rawFrom, to))
+		if err != nil {
+			return Summary{}, err
+		}
+		for _, rollup := range rollups {
+			summary.Checks += rollup.Checks
+			summary.Failures += rollup.Failures
+			summary.Degraded += rollup.Degraded
+			summary.MaxLatency = max(summary.MaxLatency, rollup.LatencyMax)
+			latency += rollup.LatencySum
+		}
+	}
+	if to.After(rawFrom) {
+		results, err := s.Results(monitor, maxTime(from, rawFrom), to)
+		if err != nil {
+			return Summary{}, err
+		}
+		for _, result := range results {
+			summary.Checks++
+			switch result.Status {
+			case "failure":
+				summary.Failures++
+			case "degraded":
+				summary.Degraded++
+			}
+			summary.MaxLatency = max(summary.MaxLatency, result.ResponseTime)
+			latency += result.ResponseTime
+		}
+	}
+	if summary.Checks > 0 {
+		summary.AverageLatency = latency / time.Duration(summary.Checks)
+	}
+	return summary, nil
+}
+
+// Prune deletes results older than the raw retention and rollups older than the retention
+func (s *Store) Prune(now time.Time) error {
+	rawCutoff := timeKey(now.Add(-s.opts.RawRetention).Truncate(time.Hour))
+	rollupCutoff := timeKey(now.Add(-s.opts.Retention).Truncate(time.Hour))
+	return s.db.Update(func(tx *bolt.Tx) error {
+		var empty [][]byte
+		err := tx.ForEach(func(name []byte, root *bolt.Bucket) error {
+			if err := deleteBefore(root.Bucket(resultsBucket), rawCutoff); err != nil {
+				return err
+			}
+			if err := deleteBefore(root.Bucket(hourlyBucket), rollupCutoff); err != nil {
+				return err
+			}
+			if hourly := root.Bucket(hourlyBucket); hourly == nil || isEmpty(hourly) {
+				empty = append(empty, bytes.Clone(name))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, name := range empty {
+			if err := tx.DeleteBucket(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Start prunes the store every hour until ctx is done and then closes it; it lets
+// the manager own the store's lifecycle
+func (s *Store) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("check-history")
+	ticker := time.NewTicker(pruneInterval)
+	defer ticker.Stop()
+	for {
+		if err := s.Prune(time.Now()); err != nil {
+			logger.Error(err, "Failed to prune check history")
+		}
+		select {
+		case <-ctx.Done():
+			return s.Close()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Store) scan(monitor string, bucket []byte, from, to time.Time, fn func(key, value []byte) error) error {
+	end := timeKey(to)
+	return s.db.View(func(tx *bolt.Tx) error {
+		root := tx.Bucket([]byte(monitor))
+		if root == nil {
+			return nil
+		}
+		b := root.Bucket(bucket)
+		if b == nil {
+			return nil
+		}
+		c := b.Cursor()
+		for key, value := c.Seek(timeKey(from)); key != nil && bytes.Compare(key, end) < 0; key, value = c.Next() {
+			if err := fn(key, value); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func deleteBefore(b *bolt.Bucket, cutoff []byte) error {
+	if b == nil {
+		return nil
+	}
+	c := b.Cursor()
+	for key, _ := c.First(); key != nil && bytes.Compare(key, cutoff) < 0; key, _ = c.First() {
+		if err := c.Delete(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func isEmpty(b *bolt.Bucket) bool {
+	key, _ := b.Cursor().First()
+	return key == nil
+}
+
+func (r *Rollup) add(result Result) {
+	r.Checks++
+	switch result.Status {
+	case "failure":
+		r.Failures++
+	case "degraded":
+		r.Degraded++
+	}
+	r.LatencySum += result.ResponseTime
+	r.LatencyMax = max(r.LatencyMax, result.ResponseTime)
+}
+
+// timeKey encodes t as big-endian Unix nanoseconds so that keys sort by time;
+// times before the epoch, such as the zero time of an open range, map to the first key
+func timeKey(t time.Time) []byte {
+	key := make([]byte, 8)
+	if t.After(time.Unix(0, 0)) {
+		binary.BigEndian.PutUint64(key, uint64(t.UnixNano()))
+	}
+	return key
+}
+
+// encodeResult packs a result as status code, response time varint and message
+func encodeResult(result Result) []byte {
+	code := byte(len(statuses))
+	for i, status := range statuses {
+		if status == result.Status {
+			code = byte(i)
+		}
+	}
+	value := []byte{code}
+	value = binary.AppendUvarint(value, uint64(max(result.ResponseTime, 0)))
+	return append(value, result.Message...)
+}
+
+func decodeResult(key, value []byte) (Result, error) {
+	if len(key) != 8 || len(value) < 2 {
+		return Result{}, fmt.Errorf("invalid entry")
+	}
+	latency, n := binary.Uvarint(value[1:])
+	if n <= 0 {
+		return Result{}, fmt.Errorf("invalid response time")
+	}
+	status := "unknown"
+	if code := int(value[0]); code < len(statuses) {
+		status = statuses[code]
+	}
+	return Result{
+		Time:         time.Unix(0, int64(binary.BigEndian.Uint64(key))).UTC(),
+		Status:       status,
+		ResponseTime: time.Duration(latency),
+		Message:      string(value[1+n:]),
+	}, nil
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,179 @@
+package store
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func openTestStore(t *testing.T, opts Options) *Store {
+	t.Helper()
+	s, err := Open(filepath.Join(t.TempDir(), "history.db"), opts)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	t.Cleanup(func() { _ = s.Close() })
+	return s
+}
+
+func TestStore_RecordAndQuery(t *testing.T) {
+	s := openTestStore(t, Options{})
+	start := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	for i := range 90 {
+		status := "success"
+		switch {
+		case i%30 == 0:
+			status = "failure"
+		case i%10 == 0:
+			status = "degraded"
+		}
+		err := s.Record("default/checkout", Result{
+			Time:         start.Add(time.Duration(i) * time.Minute),
+			Status:       status,
+			ResponseTime: time.Duration(i+1) * time.Millisecond,
+			Message:      "HTTP 200",
+		})
+		if err != nil {
+			t.Fatalf("Record() error = %v", err)
+		}
+	}
+	if err := s.Record("default/other", Result{Time: start, Status: "failure"}); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+
+	results, err := s.Results("default/checkout", start.Add(30*time.Minute), start.Add(40*time.Minute))
+	if err != nil {
+		t.Fatalf("Results() error = %v", err)
+	}
+	if len(results) != 10 {
+		t.Fatalf("Results() returned %d results, want 10", len(results))
+	}
+	want := Result{Time: start.Add(30 * time.Minute), Status: "failure", ResponseTime: 31 * time.Millisecond, Message: "HTTP 200"}
+	if results[0] != want {
+		t.Errorf("Results()[0] = %+v, want %+v", results[0], want)
+	}
+
+	rollups, err := s.Rollups("default/checkout", start, start.Add(2*time.Hour))
+	if err != nil {
+		t.Fatalf("Rollups() error = %v", err)
+	}
+	if len(rollups) != 2 {
+		t.Fatalf("Rollups() returned %d rollups, want 2", len(rollups))
+	}
+	if r := rollups[0]; r.Checks != 60 || r.Failures != 2 || r.Degraded != 4 || r.LatencyMax != 60*time.Millisecond {
+		t.Errorf("Rollups()[0] = %+v, want 60 checks, 2 failures, 4 degraded and 60ms max latency", r)
+	}
+
+	summary, err := s.Summarize("default/checkout", start, start.Add(2*time.Hour), start.Add(2*time.Hour))
+	if err != nil {
+		t.Fatalf("Summarize() error = %v", err)
+	}
+	if summary.Checks != 90 || summary.Failures != 3 || summary.AverageLatency != 45500*time.Microsecond {
+		t.Errorf("Summarize() = %+v, want 90 checks, 3 failures and 45.5ms average latency", summary)
+	}
+	if got := summary.Availability(); got < 0.9666 || got > 0.9667 {
+		t.Errorf("Availability() = %v, want 0.9667", got)
+	}
+}
+
+func TestStore_PruneDownsamples(t *testing.T) {
+	s := openTestStore(t, Options{Retention: 30 * 24 * time.Hour, RawRetention: 24 * time.Hour})
+	now := time.Date(2025, 6, 30, 12, 30, 0, 0, time.UTC)
+
+	for _, age := range []time.Duration{40 * 24 * time.Hour, 10 * 24 * time.Hour, 10*24*time.Hour + time.Minute, time.Hour} {
+		if err := s.Record("default/checkout", Result{Time: now.Add(-age), Status: "failure", ResponseTime: time.Second}); err != nil {
+			t.Fatalf("Record() error = %v", err)
+		}
+	}
+	if err := s.Record("default/removed", Result{Time: now.Add(-40 * 24 * time.Hour), Status: "success"}); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	if err := s.Prune(now); err != nil {
+		t.Fatalf("Prune() error = %v", err)
+	}
+
+	results, err := s.Results("default/checkout", time.Time{}, now)
+	if err != nil {
+		t.Fatalf("Results() error = %v", err)
+	}
+	if len(results) != 1 {
+		t.Errorf("Results() after Prune() returned %d results, want only the last hour's", len(results))
+	}
+	rollups, err := s.Rollups("default/checkout", time.Time{}, now)
+	if err != nil {
+		t.Fatalf("Rollups() error = %v", err)
+	}
+	if len(rollups) != 2 {
+		t.Errorf("Rollups() after Prune() returned %d rollups, want 2", len(rollups))
+	}
+
+	// Ten days ago only the rollup is left, but it still counts in a summary
+	summary, err := s.Summarize("default/checkout", now.Add(-20*24*time.Hour), now, now)
+	if err != nil {
+		t.Fatalf("Summarize() error = %v", err)
+	}
+	if summary.Checks != 3 || summary.Failures != 3 {
+		t.Errorf("Summarize() = %+v, want 3 checks and 3 failures", summary)
+	}
+
+	removed, err := s.Rollups("default/removed", time.Time{}, now)
+	if err != nil {
+		t.Fatalf("Rollups() error = %v", err)
+	}
+	if len(removed) != 0 {
+		t.Errorf("expired monitor still has %d rollups", len(removed))
+	}
+}
+
+func TestStore_Handler(t *testing.T) {
+	s := openTestStore(t, Options{})
+	now := time.Now().UTC().Truncate(time.Second)
+	for i := range 3 {
+		status := "success"
+		if i == 2 {
+			status = "failure"
+		}
+		if err := s.Record("default/checkout", Result{Time: now.Add(-time.Duration(i) * time.Minute), Status: status}); err != nil {
+			t.Fatalf("Record() error = %v", err)
+		}
+	}
+
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+		wantCount  int
+	}{
+		{name: "raw results of the last day", query: "namespace=default&name=checkout", wantStatus: http.StatusOK, wantCount: 3},
+		{name: "hourly rollups", query: "namespace=default&name=checkout&resolution=hourly", wantStatus: http.StatusOK},
+		{name: "missing monitor", query: "namespace=default", wantStatus: http.StatusBadRequest},
+		{name: "invalid time", query: "namespace=default&name=checkout&from=yesterday", wantStatus: http.StatusBadRequest},
+		{name: "invalid resolution", query: "namespace=default&name=checkout&resolution=daily", wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			s.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, HandlerPath+"?"+tt.query, nil))
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var response historyResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+				t.Fatalf("invalid response: %v", err)
+			}
+			if len(response.Results) != tt.wantCount {
+				t.Errorf("got %d results, want %d", len(response.Results), tt.wantCount)
+			}
+			if response.Summary.Checks != 3 || response.Summary.Failures != 1 {
+				t.Errorf("summary = %+v, want 3 checks and 1 failure", response.Summary)
+			}
+		})
+	}
+}
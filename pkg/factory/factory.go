@@ -1,8 +1,12 @@
 package factory
 
 import (
+	"context"
+	"errors"
 	"fmt"
 
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
 	"github.com/LiciousTech/endpoint-monitoring-operator/api/v1alpha1"
 	"github.com/LiciousTech/endpoint-monitoring-operator/internal/driver"
 	"github.com/LiciousTech/endpoint-monitoring-operator/internal/notifier"
@@ -70,31 +74,128 @@ func (c *CompositeNotifier) SendAlert(status string, msg string) error {
 }
 
 // DriverFactory creates monitoring drivers based on configuration
-type DriverFactory struct{}
-
-// NewDriver creates a driver instance based on the driver type
-func NewDriver(driverType string, endpoint string, monitor *v1alpha1.EndpointMonitor) (driver.Driver, error) {
-	factory := &DriverFactory{}
-	return factory.CreateDriver(driverType, endpoint, monitor)
+type DriverFactory struct {
+	// Client resolves Secrets referenced by the monitor and reads workloads for the kubernetes driver
+	Client client.Reader
+	// AllowCrossNamespaceTargets lets kubernetesCheck.namespace name a namespace other than the monitor's
+	AllowCrossNamespaceTargets bool
 }
 
 // CreateDriver implements the factory pattern for drivers
-func (f *DriverFactory) CreateDriver(driverType string, endpoint string, monitor *v1alpha1.EndpointMonitor) (driver.Driver, error) {
+func (f *DriverFactory) CreateDriver(ctx context.Context, driverType string, endpoint string, monitor *v1alpha1.EndpointMonitor) (driver.Driver, error) {
+	d, err := f.createBaseDriver(ctx, driverType, endpoint, monitor)
+	var secretErr *secretError
+	if errors.As(err, &secretErr) {
+		// A missing Secret or key fails every check until it is fixed, so the monitor keeps alerting
+		return &unresolvedDriver{driverType: driverType, endpoint: endpoint, err: err}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if monitor.Spec.SuccessExpression != "" {
+		return driver.NewExpressionDriver(d, monitor.Spec.SuccessExpression)
+	}
+	return d, nil
+}
+
+func (f *DriverFactory) createBaseDriver(ctx context.Context, driverType string, endpoint string, monitor *v1alpha1.EndpointMonitor) (driver.Driver, error) {
 	switch driverType {
 	case "http":
 		return driver.NewHTTPDriver(endpoint)
 	case "http-json":
 		return driver.NewHTTPJSONDriver(endpoint, monitor.Spec.HttpJsonCheck)
 	case "tcp":
-		return driver.NewTCPDriver(endpoint)
+		return driver.NewTCPDriver(endpoint, monitor.Spec.TcpCheck)
+	case "udp":
+		return driver.NewUDPDriver(endpoint, monitor.Spec.UdpCheck)
 	case "dns":
-		return driver.NewDNSDriver(endpoint)
+		return driver.NewDNSDriver(endpoint, monitor.Spec.DnsCheck)
 	case "ping":
-		return driver.NewPingDriver(endpoint)
+		return driver.NewPingDriver(endpoint, monitor.Spec.PingCheck)
+	case "postgres":
+		var creds *driver.Credentials
+		if check := monitor.Spec.PostgresCheck; check != nil && check.CredentialsSecretRef != nil {
+			var err error
+			if creds, err = f.resolveCredentials(ctx, monitor.Namespace, check.CredentialsSecretRef); err != nil {
+				return nil, err
+			}
+		}
+		return driver.NewPostgresDriver(endpoint, monitor.Spec.PostgresCheck, creds)
+	case "mysql":
+		var creds *driver.Credentials
+		if check := monitor.Spec.MysqlCheck; check != nil && check.CredentialsSecretRef != nil {
+			var err error
+			if creds, err = f.resolveCredentials(ctx, monitor.Namespace, check.CredentialsSecretRef); err != nil {
+				return nil, err
+			}
+		}
+		return driver.NewMySQLDriver(endpoint, monitor.Spec.MysqlCheck, creds)
+	case "redis":
+		var creds *driver.Credentials
+		if check := monitor.Spec.RedisCheck; check != nil && check.CredentialsSecretRef != nil {
+			var err error
+			if creds, err = f.resolveCredentials(ctx, monitor.Namespace, check.CredentialsSecretRef); err != nil {
+				return nil, err
+			}
+		}
+		return driver.NewRedisDriver(endpoint, monitor.Spec.RedisCheck, creds)
+	case "kafka":
+		var creds *driver.Credentials
+		var tlsMaterial *driver.TLSMaterial
+		if check := monitor.Spec.KafkaCheck; check != nil {
+			var err error
+			if check.SASL != nil {
+				if creds, err = f.resolveCredentials(ctx, monitor.Namespace, &check.SASL.CredentialsSecretRef); err != nil {
+					return nil, err
+				}
+			}
+			if check.TLSSecretRef != nil {
+				if tlsMaterial, err = f.resolveTLSMaterial(ctx, monitor.Namespace, check.TLSSecretRef); err != nil {
+					return nil, err
+				}
+			}
+		}
+		return driver.NewKafkaDriver(endpoint, monitor.Spec.KafkaCheck, creds, tlsMaterial)
 	case "trino":
-		return driver.NewTrinoDriver(endpoint)
-	case "opensearch":
-		return driver.NewOpenSearchDriver(endpoint)
+		return driver.NewTrinoDriver(endpoint, monitor.Spec.TrinoCheck)
+	case "opensearch", "elasticsearch":
+		var creds *driver.Credentials
+		var apiKey string
+		if check := monitor.Spec.OpenSearchCheck; check != nil {
+			var err error
+			if check.CredentialsSecretRef != nil {
+				if creds, err = f.resolveCredentials(ctx, monitor.Namespace, check.CredentialsSecretRef); err != nil {
+					return nil, err
+				}
+			}
+			if check.APIKeySecretRef != nil {
+				if apiKey, err = f.resolveSecretValue(ctx, monitor.Namespace, check.APIKeySecretRef); err != nil {
+					return nil, err
+				}
+			}
+		}
+		return driver.NewOpenSearchDriver(driverType, endpoint, monitor.Spec.OpenSearchCheck, creds, apiKey)
+	case "promql":
+		var creds *driver.Credentials
+		var token string
+		if check := monitor.Spec.PromqlCheck; check != nil {
+			var err error
+			if check.CredentialsSecretRef != nil {
+				if creds, err = f.resolveCredentials(ctx, monitor.Namespace, check.CredentialsSecretRef); err != nil {
+					return nil, err
+				}
+			}
+			if check.BearerTokenSecretRef != nil {
+				if token, err = f.resolveSecretValue(ctx, monitor.Namespace, check.BearerTokenSecretRef); err != nil {
+					return nil, err
+				}
+			}
+		}
+		return driver.NewPromQLDriver(endpoint, monitor.Spec.PromqlCheck, creds, token)
+	case "kubernetes":
+		return driver.NewKubernetesDriver(f.Client, endpoint, monitor.Namespace, monitor.Spec.KubernetesCheck,
+			f.AllowCrossNamespaceTargets)
 	default:
 		return nil, fmt.Errorf("unsupported driver type: %s", driverType)
 	}
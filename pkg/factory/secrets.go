@@ -0,0 +1,131 @@
+package factory
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/LiciousTech/endpoint-monitoring-operator/api/v1alpha1"
+	"github.com/LiciousTech/endpoint-monitoring-operator/internal/driver"
+)
+
+// secretError is a referenced Secret or key that cannot be read. Unlike an invalid spec it
+// can be fixed without touching the monitor, so it is reported as a failed check.
+type secretError struct {
+	err error
+}
+
+func (e *secretError) Error() string {
+	return e.err.Error()
+}
+
+func (e *secretError) Unwrap() error {
+	return e.err
+}
+
+// unresolvedDriver stands in for a driver whose Secret could not be resolved and fails every check
+type unresolvedDriver struct {
+	driverType string
+	endpoint   string
+	err        error
+}
+
+func (u *unresolvedDriver) Check() (*driver.CheckResult, error) {
+	return &driver.CheckResult{
+		Success: false,
+		Error:   u.err,
+		Message: fmt.Sprintf("%s check failed: %v", u.driverType, u.err),
+	}, nil
+}
+
+func (u *unresolvedDriver) GetEndpoint() string {
+	return u.endpoint
+}
+
+func (u *unresolvedDriver) GetType() string {
+	return u.driverType
+}
+
+// resolveCredentials reads the username and password referenced by ref from a Secret in namespace
+func (f *DriverFactory) resolveCredentials(ctx context.Context, namespace string, ref *v1alpha1.CredentialsSecretRef) (*driver.Credentials, error) {
+	if f.Client == nil {
+		return nil, fmt.Errorf("cannot resolve secret %q: no client configured", ref.Name)
+	}
+
+	var secret corev1.Secret
+	if err := f.Client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Name}, &secret); err != nil {
+		return nil, &secretError{fmt.Errorf("failed to get credentials secret %q: %w", ref.Name, err)}
+	}
+
+	usernameKey := ref.UsernameKey
+	if usernameKey == "" {
+		usernameKey = "username"
+	}
+	passwordKey := ref.PasswordKey
+	if passwordKey == "" {
+		passwordKey = "password"
+	}
+
+	password, ok := secret.Data[passwordKey]
+	if !ok {
+		return nil, &secretError{fmt.Errorf("credentials secret %q has no key %q", ref.Name, passwordKey)}
+	}
+
+	// The username is optional so that password-only services (e.g. Redis) can share the reference
+	return &driver.Credentials{
+		Username: string(secret.Data[usernameKey]),
+		Password: string(password),
+	}, nil
+}
+
+// resolveTLSMaterial reads the CA bundle and optional client key pair referenced by ref from a Secret in namespace
+func (f *DriverFactory) resolveTLSMaterial(ctx context.Context, namespace string, ref *v1alpha1.TLSSecretRef) (*driver.TLSMaterial, error) {
+	if f.Client == nil {
+		return nil, fmt.Errorf("cannot resolve secret %q: no client configured", ref.Name)
+	}
+
+	var secret corev1.Secret
+	if err := f.Client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Name}, &secret); err != nil {
+		return nil, &secretError{fmt.Errorf("failed to get TLS secret %q: %w", ref.Name, err)}
+	}
+
+	caKey, certKey, keyKey := ref.CAKey, ref.CertKey, ref.KeyKey
+	if caKey == "" {
+		caKey = "ca.crt"
+	}
+	if certKey == "" {
+		certKey = corev1.TLSCertKey
+	}
+	if keyKey == "" {
+		keyKey = corev1.TLSPrivateKeyKey
+	}
+
+	material := &driver.TLSMaterial{
+		CA:   secret.Data[caKey],
+		Cert: secret.Data[certKey],
+		Key:  secret.Data[keyKey],
+	}
+	if (len(material.Cert) == 0) != (len(material.Key) == 0) {
+		return nil, &secretError{fmt.Errorf("TLS secret %q must contain both %q and %q for client authentication", ref.Name, certKey, keyKey)}
+	}
+	return material, nil
+}
+
+// resolveSecretValue reads a single key referenced by ref from a Secret in namespace
+func (f *DriverFactory) resolveSecretValue(ctx context.Context, namespace string, ref *v1alpha1.SecretKeyRef) (string, error) {
+	if f.Client == nil {
+		return "", fmt.Errorf("cannot resolve secret %q: no client configured", ref.Name)
+	}
+
+	var secret corev1.Secret
+	if err := f.Client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Name}, &secret); err != nil {
+		return "", &secretError{fmt.Errorf("failed to get secret %q: %w", ref.Name, err)}
+	}
+	value, ok := secret.Data[ref.Key]
+	if !ok {
+		return "", &secretError{fmt.Errorf("secret %q has no key %q", ref.Name, ref.Key)}
+	}
+	return string(value), nil
+}
@@ -0,0 +1,53 @@
+package factory
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/LiciousTech/endpoint-monitoring-operator/api/v1alpha1"
+)
+
+func TestCreateDriverUnresolvedSecret(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "orders-db", Namespace: "shop"},
+		Data:       map[string][]byte{"username": []byte("monitor")},
+	}).Build()
+	f := &DriverFactory{Client: c}
+
+	tests := []struct {
+		name      string
+		secret    string
+		contained string
+	}{
+		{name: "missing secret", secret: "deleted", contained: `failed to get credentials secret "deleted"`},
+		{name: "missing key", secret: "orders-db", contained: `credentials secret "orders-db" has no key "password"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			monitor := &v1alpha1.EndpointMonitor{
+				ObjectMeta: metav1.ObjectMeta{Name: "orders", Namespace: "shop"},
+				Spec: v1alpha1.EndpointMonitorSpec{
+					Driver:        "postgres",
+					PostgresCheck: &v1alpha1.PostgresCheck{CredentialsSecretRef: &v1alpha1.CredentialsSecretRef{Name: tt.secret}},
+				},
+			}
+			d, err := f.CreateDriver(context.Background(), "postgres", "orders-db.shop.svc:5432", monitor)
+			if err != nil {
+				t.Fatalf("expected a failing driver rather than an error, got %v", err)
+			}
+			result, err := d.Check()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Success || !strings.Contains(result.Message, tt.contained) {
+				t.Errorf("expected a failed check containing %q, got success=%t: %s", tt.contained, result.Success, result.Message)
+			}
+		})
+	}
+}